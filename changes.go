@@ -0,0 +1,109 @@
+package squint
+
+import (
+	sqldriver "database/sql/driver"
+	"reflect"
+	"time"
+)
+
+// Changeset is a set of changed columns and their new values
+type Changeset struct {
+	cols  []string
+	binds []interface{}
+}
+
+// Changes compares two values of the same struct type and returns a
+// Changeset of the columns that differ, with their values from after.
+// This is meant to follow SET in an UPDATE statement:
+//
+//	if changes, ok := b.Changes(oldUser, newUser); ok {
+//	  b.Build("UPDATE users SET", changes, "WHERE id =", newUser.ID)
+//	}
+//
+// The boolean reports whether anything changed at all. Fields are mapped
+// as they would be for an UPDATE, so noupdate fields are never included.
+// A driver.Valuer is compared by its value, and a time.Time by the
+// instant it represents.
+func (b *Builder) Changes(before, after interface{}) (Changeset, bool) {
+	var cs Changeset
+
+	bv, av := reflect.ValueOf(before), reflect.ValueOf(after)
+	for bv.Kind() == reflect.Ptr && !bv.IsNil() {
+		bv = bv.Elem()
+	}
+
+	for av.Kind() == reflect.Ptr && !av.IsNil() {
+		av = av.Elem()
+	}
+
+	if bv.Kind() != reflect.Struct || av.Kind() != reflect.Struct || bv.Type() != av.Type() {
+		return cs, false
+	}
+
+	// keep all columns so the two sides line up
+	q := query{opt: b.Options, keepAll: true, write: writeUpdate}
+	cols, oldBinds := q.siftStruct(&bv)
+	_, newBinds := q.siftStruct(&av)
+
+	for i, col := range cols {
+		if !equalValues(oldBinds[i], newBinds[i]) {
+			cs.cols = append(cs.cols, col)
+			cs.binds = append(cs.binds, newBinds[i])
+		}
+	}
+
+	return cs, len(cs.cols) > 0
+}
+
+// Changes : package level version, using default options
+func Changes(before, after interface{}) (Changeset, bool) {
+	return NewBuilder().Changes(before, after)
+}
+
+// addChangeset adds a changeset to the query
+func (q *query) addChangeset(cs Changeset) {
+	q.addColumns(q.state(), cs.cols, cs.binds)
+}
+
+// equalValues reports whether two bind values are the same. Times
+// are equal if they are the same instant, regardless of location.
+func equalValues(a, b interface{}) bool {
+	a, b = valueOf(a), valueOf(b)
+
+	if at, ok := timeOf(a); ok {
+		if bt, ok := timeOf(b); ok {
+			return at.Equal(bt)
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// timeOf returns the time held by a time.Time or non-nil *time.Time
+func timeOf(in interface{}) (time.Time, bool) {
+	switch t := in.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// valueOf resolves a driver.Valuer to its value, for comparison
+func valueOf(in interface{}) interface{} {
+	if valuer, ok := in.(sqldriver.Valuer); ok {
+		if rv := reflect.ValueOf(valuer); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+
+	return in
+}
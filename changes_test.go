@@ -0,0 +1,73 @@
+package squint_test
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestChanges() {
+	type user struct {
+		ID      int            `db:"id"`
+		Name    string         `db:"name"`
+		Email   sql.NullString `db:"email"`
+		Created string         `db:"created,noupdate"`
+	}
+
+	before := user{1, "Frank", sql.NullString{String: "a@b.c", Valid: true}, "today"}
+
+	s.Run("none", func() {
+		after := before
+		after.Created = "tomorrow"
+
+		_, ok := squint.Changes(before, &after)
+		s.False(ok)
+	})
+
+	s.Run("some", func() {
+		after := before
+		after.Name = "Hank"
+		after.Email = sql.NullString{}
+
+		changes, ok := s.q.Changes(&before, after)
+		s.True(ok)
+
+		s.check(
+			"UPDATE users SET name = ?, email = ? WHERE id = ?",
+			binds{"Hank", sql.NullString{}, 1},
+			"UPDATE users SET", changes, "WHERE id =", after.ID,
+		)
+	})
+
+	s.Run("mismatch", func() {
+		_, ok := squint.Changes(before, H{"id": 1})
+		s.False(ok)
+
+		_, ok = squint.Changes(nil, before)
+		s.False(ok)
+	})
+
+	s.Run("time", func() {
+		type event struct {
+			ID   int       `db:"id"`
+			When time.Time `db:"when"`
+		}
+
+		at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("EST", -5*3600))
+		old := event{1, at}
+
+		_, ok := squint.Changes(old, event{1, at.UTC()})
+		s.False(ok)
+
+		later := at.Add(time.Hour)
+		changes, ok := s.q.Changes(old, event{1, later})
+		s.True(ok)
+
+		s.check(
+			"UPDATE events SET when = ?",
+			binds{later},
+			"UPDATE events SET", changes,
+		)
+	})
+}
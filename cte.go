@@ -0,0 +1,70 @@
+package squint
+
+// CommonTable is a common table expression for a WITH clause
+type CommonTable struct {
+	name      string
+	recursive bool
+	bits      []interface{}
+}
+
+// withList tracks the WITH clause being built
+type withList struct {
+	start     int  // position after WITH
+	end       int  // position after the last CTE
+	recursive bool // RECURSIVE has been added
+}
+
+// CTE adds a common table expression, which is built from its own list
+// of arguments. Consecutive CTEs are combined into one WITH clause:
+//
+//	b.Build(
+//	  squint.CTE("recent", "SELECT * FROM orders WHERE created >", since),
+//	  squint.CTE("big", "SELECT * FROM recent WHERE total >", minTotal),
+//	  "SELECT * FROM big",
+//	)
+//
+// This produces:
+//
+//	WITH recent AS ( SELECT * FROM orders WHERE created > ? ),
+//	big AS ( SELECT * FROM recent WHERE total > ? ) SELECT * FROM big
+//
+// The name may include a column list, such as "totals(id, sum)".
+func CTE(name string, bits ...interface{}) CommonTable {
+	return CommonTable{name: name, bits: bits}
+}
+
+// RecursiveCTE adds a recursive common table expression. The WITH clause
+// it belongs to will be marked RECURSIVE where the database requires it,
+// i.e. not for BindAt or BindColon (sqlserver or oracle).
+func RecursiveCTE(name string, bits ...interface{}) CommonTable {
+	return CommonTable{name: name, recursive: true, bits: bits}
+}
+
+// addCTE adds a common table expression to the query
+func (q *query) addCTE(c CommonTable) {
+	if q.with.end > 0 && q.with.end == len(q.sql.val) {
+		q.sql.Add(",")
+	} else {
+		q.sql.Add("WITH")
+		q.with = withList{start: len(q.sql.val)}
+	}
+
+	if c.recursive && !q.with.recursive && q.opt.dialect.recursive() {
+		q.sql.val = q.sql.val[:q.with.start] + " RECURSIVE" + q.sql.val[q.with.start:]
+		q.with.recursive = true
+	}
+
+	q.sql.Add(c.name + " AS (")
+
+	// a CTE body could have its own WITH
+	with := q.with
+
+	for n := range c.bits {
+		q.Add(c.bits[n])
+	}
+
+	q.with = with
+
+	q.sql.Add(")")
+	q.with.end = len(q.sql.val)
+}
@@ -0,0 +1,70 @@
+package squint_test
+
+import (
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestCTE() {
+	bits := []interface{}{
+		squint.CTE("recent", "SELECT * FROM orders WHERE created >", squint.Bind("2020-01-01")),
+		squint.CTE("big", "SELECT * FROM recent WHERE", squint.If(true, "total >", 100)),
+		"SELECT * FROM big WHERE id IN", []int{1, 2},
+	}
+
+	s.check(
+		"WITH recent AS ( SELECT * FROM orders WHERE created > ? ), "+
+			"big AS ( SELECT * FROM recent WHERE total > ? ) SELECT * FROM big WHERE id IN ( ?, ? )",
+		binds{"2020-01-01", 100, 1, 2},
+		bits...,
+	)
+
+	s.check(
+		"WITH recent AS ( SELECT * FROM orders WHERE created > $1 ), "+
+			"big AS ( SELECT * FROM recent WHERE total > $2 ) SELECT * FROM big WHERE id IN ( $3, $4 )",
+		binds{"2020-01-01", 100, 1, 2},
+		append([]interface{}{squint.BindDollar()}, bits...)...,
+	)
+
+	s.Run("separate", func() {
+		s.check(
+			"WITH a AS ( SELECT 1 ) SELECT * FROM a UNION WITH b AS ( SELECT 2 ) SELECT * FROM b",
+			s.empty,
+			squint.CTE("a", "SELECT 1"), "SELECT * FROM a UNION",
+			squint.CTE("b", "SELECT 2"), "SELECT * FROM b",
+		)
+	})
+
+	s.Run("recursive", func() {
+		tree := []interface{}{
+			squint.CTE("roots", "SELECT id FROM nodes WHERE parent_id IS NULL"),
+			squint.RecursiveCTE("tree(id, depth)",
+				"SELECT id, 0 FROM roots UNION ALL",
+				"SELECT n.id, t.depth + 1 FROM nodes n JOIN tree t ON n.parent_id = t.id WHERE t.depth <", 5,
+			),
+			"SELECT * FROM tree",
+		}
+
+		s.check(
+			"WITH RECURSIVE roots AS ( SELECT id FROM nodes WHERE parent_id IS NULL ), "+
+				"tree(id, depth) AS ( SELECT id, 0 FROM roots UNION ALL "+
+				"SELECT n.id, t.depth + 1 FROM nodes n JOIN tree t ON n.parent_id = t.id WHERE t.depth < ? ) "+
+				"SELECT * FROM tree",
+			binds{5},
+			tree...,
+		)
+
+		sql, _ := s.q.Build(append([]interface{}{squint.BindAt()}, tree...)...)
+		s.Contains(sql, "WITH roots AS")
+		s.Contains(sql, "WHERE t.depth < @p1 )")
+	})
+
+	s.Run("nested", func() {
+		s.check(
+			"WITH a AS ( WITH b AS ( SELECT ? ) SELECT * FROM b ), c AS ( SELECT ? ) SELECT * FROM a, c",
+			binds{1, 2},
+			squint.CTE("a", squint.CTE("b", "SELECT", 1), "SELECT * FROM b"),
+			squint.CTE("c", "SELECT", 2),
+			"SELECT * FROM a, c",
+		)
+	})
+}
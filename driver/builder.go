@@ -1,6 +1,8 @@
 package driver
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 
 	"github.com/mwblythe/squint"
@@ -8,19 +10,59 @@ import (
 
 type builder struct {
 	*squint.Builder
+	style squint.Option // bind placeholder style
 }
 
 func newBuilder(b *squint.Builder) *builder {
 	return &builder{Builder: b}
 }
 
-func (b *builder) BuildNamed(query string, inVals []driver.NamedValue) (string, []interface{}) {
+func (b *builder) BuildArgs(ctx context.Context, query string, args []interface{}) (string, []interface{}) {
+	bits := make([]interface{}, len(args)+1)
+	bits[0] = query
+	copy(bits[1:], args)
+
+	return b.build(ctx, bits)
+}
+
+// build the bits, using any Builder and options from the context.
+// The bind style (if any) is applied first, then the context options.
+func (b *builder) build(ctx context.Context, bits []interface{}) (string, []interface{}) {
+	sq := b.Builder
+	if cb := ctxBuilder(ctx); cb != nil {
+		sq = cb
+	}
+
+	opts := ctxOptions(ctx)
+	if b.style != nil || len(opts) > 0 {
+		pre := make([]interface{}, 0, len(opts)+1+len(bits))
+		if b.style != nil {
+			pre = append(pre, b.style)
+		}
+
+		for _, opt := range opts {
+			pre = append(pre, opt)
+		}
+
+		bits = append(pre, bits...)
+	}
+
+	return sq.Build(bits...)
+}
+
+// namedBits returns the query and named values as bits for the Builder.
+// Named values are passed as sql.NamedArg, to keep their names.
+func namedBits(query string, inVals []driver.NamedValue) []interface{} {
 	bits := make([]interface{}, len(inVals)+1)
 	bits[0] = query
 
 	for n := range inVals {
-		bits[n+1] = inVals[n].Value
+		if inVals[n].Name != "" {
+			bits[n+1] = sql.Named(inVals[n].Name, inVals[n].Value)
+		} else {
+			bits[n+1] = inVals[n].Value
+		}
 	}
 
-	return b.Build(bits...)
+	return bits
 }
@@ -19,13 +19,17 @@ var (
 
 // sqConn is a proxy that will pre-process queries with squint Builder
 type sqConn struct {
-	conn    *sql.Conn
-	builder *builder
-	dsn     string
+	conn *sql.Conn
+	drv  *sqDriver
+	db   *sql.DB
+	inTx bool // in a transaction
+
+	release   func() error // drops the inner pool references, if held
+	connector *sqConnector // connector that opened it, if any
 }
 
-func newConn(c *sql.Conn, b *builder, dsn string) *sqConn {
-	return &sqConn{conn: c, builder: b, dsn: dsn}
+func newConn(c *sql.Conn, drv *sqDriver, db *sql.DB) *sqConn {
+	return &sqConn{conn: c, drv: drv, db: db}
 }
 
 func (c *sqConn) CheckNamedValue(*driver.NamedValue) error {
@@ -42,18 +46,33 @@ func (c *sqConn) PrepareContext(ctx context.Context, query string) (driver.Stmt,
 }
 
 func (c *sqConn) Begin() (driver.Tx, error) {
-	return c.conn.BeginTx(context.Background(), nil)
+	return c.BeginTx(context.Background(), driver.TxOptions{})
 }
 
 func (c *sqConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
-	return c.conn.BeginTx(ctx, &sql.TxOptions{
+	tx, err := c.conn.BeginTx(ctx, &sql.TxOptions{
 		Isolation: sql.IsolationLevel(opts.Isolation),
 		ReadOnly:  opts.ReadOnly,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.inTx = true
+
+	return sqTx{tx, c}, nil
 }
 
 func (c *sqConn) Close() error {
-	return c.conn.Close()
+	err := c.conn.Close()
+
+	if c.release != nil {
+		if rerr := c.release(); err == nil {
+			err = rerr
+		}
+	}
+
+	return err
 }
 
 func (c *sqConn) Ping(ctx context.Context) error {
@@ -61,13 +80,44 @@ func (c *sqConn) Ping(ctx context.Context) error {
 }
 
 func (c *sqConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	query, binds := c.builder.BuildNamed(query, args)
-	return c.conn.ExecContext(ctx, query, binds...)
+	var res sql.Result
+
+	info := c.drv.newInfo(ctx, query, args)
+	err := c.drv.observe(ctx, &info, func(ctx context.Context) (err error) {
+		if res, err = c.conn.ExecContext(ctx, info.SQL, info.Binds...); err == nil {
+			if n, err := res.RowsAffected(); err == nil {
+				info.RowsAffected = n
+			}
+		}
+
+		return err
+	})
+
+	c.checkSlow(ctx, info, c.conn)
+
+	return res, err
 }
 
 func (c *sqConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	query, binds := c.builder.BuildNamed(query, args)
-	r, err := c.conn.QueryContext(ctx, query, binds...)
+	var r *sql.Rows
+
+	info := c.drv.newInfo(ctx, query, args)
+
+	on, err := c.reader(ctx, info.SQL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.drv.observe(ctx, &info, func(ctx context.Context) (err error) {
+		r, err = on.QueryContext(ctx, info.SQL, info.Binds...)
+		return err
+	})
+
+	if err != nil {
+		c.checkSlow(ctx, info, on)
+		return &sqRows{Rows: r}, err
+	}
 
-	return sqRows{r}, err
+	// wait for the rows to close, so that EXPLAIN can use the connection
+	return &sqRows{Rows: r, onClose: func() { c.checkSlow(ctx, info, on) }}, nil
 }
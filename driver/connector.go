@@ -0,0 +1,100 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// compile-time interface checks
+var (
+	_ driver.Connector = (*sqConnector)(nil)
+	_ io.Closer        = (*sqConnector)(nil)
+)
+
+// NewConnector returns a squint-enabled connector, for use with sql.OpenDB.
+// Unlike Register, nothing is registered globally, so any number of
+// differently configured connectors can be created.
+//
+// to is either the name of the original sql driver, e.g. "mysql", or
+// a driver.Connector for it. With a name, use the DSN option to give the
+// data source name:
+//
+//	c, err := driver.NewConnector("mysql", driver.DSN(dsn))
+//	db := sql.OpenDB(c)
+//
+// Options are the same as for Register, though Name does not apply.
+// With a driver.Connector, Replicas does not apply either, and there is
+// no driver name to infer the bind placeholder style from, so
+// Placeholder or a Builder with a bind option must be given.
+func NewConnector(to interface{}, o ...Option) (driver.Connector, error) {
+	drv := newDriver(o...)
+
+	switch t := to.(type) {
+	case string:
+		var err error
+
+		drv.toDriver = t
+		if err = drv.resolveStyle(); err != nil {
+			return nil, err
+		}
+
+		return drv.OpenConnector(drv.dsn)
+	case driver.Connector:
+		if err := drv.resolveStyle(); err != nil {
+			return nil, err
+		}
+
+		if len(drv.replicas) > 0 {
+			return nil, errors.New("replicas need a driver name to connect")
+		}
+
+		return &sqConnector{drv: drv, db: sql.OpenDB(t)}, nil
+	default:
+		return nil, fmt.Errorf("cannot connect to %T: need driver name or driver.Connector", to)
+	}
+}
+
+// sqConnector is a squint proxy connector, for a given inner pool
+type sqConnector struct {
+	drv  *sqDriver
+	db   *sql.DB
+	dsns []string // DSNs of shared inner pools, if any
+	once sync.Once
+	err  error
+}
+
+// Close releases the inner pools, closing any that are no longer used.
+// It is called by driver.Close and, as of Go 1.17, when the sql.DB is
+// closed. Only the first call has any effect.
+func (c *sqConnector) Close() error {
+	c.once.Do(func() {
+		if c.dsns == nil {
+			c.err = c.db.Close()
+		} else {
+			c.err = c.drv.release(c.dsns...)
+		}
+	})
+
+	return c.err
+}
+
+func (c *sqConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := newConn(conn, c.drv, c.db)
+	sc.connector = c
+
+	return sc, nil
+}
+
+func (c *sqConnector) Driver() driver.Driver {
+	return c.drv
+}
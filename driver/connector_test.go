@@ -0,0 +1,98 @@
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+// mockConnector connects to a sqlmock DSN
+type mockConnector struct {
+	drv sqldriver.Driver
+	dsn string
+}
+
+func (c mockConnector) Connect(context.Context) (sqldriver.Conn, error) {
+	return c.drv.Open(c.dsn)
+}
+
+func (c mockConnector) Driver() sqldriver.Driver {
+	return c.drv
+}
+
+func (s *DriverSuite) TestConnector() {
+	dsn := "connector-tests"
+
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().Nil(err)
+
+	defer mockDB.Close()
+
+	builder := squint.NewBuilder(squint.BindDollar())
+
+	s.Run("name", func() {
+		c, err := driver.NewConnector("sqlmock", driver.DSN(dsn), driver.Builder(builder))
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		mock.ExpectExec("delete from junk where id = $1").WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err = db.ExecContext(s.ctx, "delete from junk where id =", 10)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("connector", func() {
+		c, err := driver.NewConnector(
+			mockConnector{mockDB.Driver(), dsn},
+			driver.Placeholder(squint.BindQuestion()),
+		)
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		mock.ExpectQuery("select id from junk where id IN ( ?, ? )").WithArgs(1, 2).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1),
+		)
+
+		var id int
+		s.Nil(db.QueryRowContext(s.ctx, "select id from junk where id IN", []int{1, 2}).Scan(&id))
+		s.Equal(1, id)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("invalid", func() {
+		c, err := driver.NewConnector(10)
+		s.NotNil(err)
+		s.Nil(c)
+	})
+
+	s.Run("no style", func() {
+		c, err := driver.NewConnector(mockConnector{mockDB.Driver(), dsn})
+		s.NotNil(err)
+		s.Nil(c)
+	})
+}
+
+func (s *DriverSuite) TestOpenConnector() {
+	drv, ok := s.db.Driver().(sqldriver.DriverContext)
+	s.Require().True(ok)
+
+	c, err := drv.OpenConnector("driver-tests")
+	s.Nil(err)
+	s.Equal(s.db.Driver(), c.Driver())
+
+	s.mock.ExpectPing()
+
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	s.Nil(db.PingContext(s.ctx))
+	s.Nil(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/mwblythe/squint"
+)
+
+// ctxKey is the type of context keys used by the squint driver
+type ctxKey int
+
+// context keys
+const (
+	primaryKey ctxKey = iota
+	optionsKey
+	builderKey
+)
+
+// UsePrimary returns a context that sends queries to the primary
+// database, even where they would otherwise go to a replica. This
+// allows reading your own writes:
+//
+//	_, err = db.ExecContext(ctx, "insert into users", user)
+//	row := db.QueryRowContext(driver.UsePrimary(ctx), "select * from users where id =", user.ID)
+//
+// The driver can't tell when a *sql.Conn is pinned, so reads on one
+// still go to a replica. Use UsePrimary with it, or a transaction, to
+// keep them on the same connection as its writes.
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+// usePrimary reports whether the context requires the primary database
+func usePrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey).(bool)
+	return v
+}
+
+// WithOptions returns a context with squint options to apply to queries
+// run with it, after those of the Builder. Options from any outer
+// context are kept, with these following them:
+//
+//	ctx = driver.WithOptions(ctx, squint.OmitEmpty(), squint.Log(true))
+//	_, err = db.ExecContext(ctx, "insert into users", user)
+func WithOptions(ctx context.Context, opts ...squint.Option) context.Context {
+	prev := ctxOptions(ctx)
+	all := make([]squint.Option, 0, len(prev)+len(opts))
+	all = append(append(all, prev...), opts...)
+
+	return context.WithValue(ctx, optionsKey, all)
+}
+
+// WithBuilder returns a context with a squint Builder to use for queries
+// run with it, in place of the driver's Builder
+func WithBuilder(ctx context.Context, b *squint.Builder) context.Context {
+	return context.WithValue(ctx, builderKey, b)
+}
+
+// ctxOptions returns the squint options in the context, if any
+func ctxOptions(ctx context.Context) []squint.Option {
+	opts, _ := ctx.Value(optionsKey).([]squint.Option)
+	return opts
+}
+
+// ctxBuilder returns the squint Builder in the context, if any
+func ctxBuilder(ctx context.Context) *squint.Builder {
+	b, _ := ctx.Value(builderKey).(*squint.Builder)
+	return b
+}
@@ -0,0 +1,52 @@
+package driver_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestWithOptions() {
+	type user struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	s.Run("options", func() {
+		ctx := driver.WithOptions(s.ctx, squint.OmitEmpty())
+		ctx = driver.WithOptions(ctx, squint.BindDollar())
+
+		s.mock.ExpectExec("INSERT INTO users ( id ) VALUES ( $1 )").WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := s.db.ExecContext(ctx, "INSERT INTO users", user{ID: 10})
+		s.Nil(err)
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("builder", func() {
+		ctx := driver.WithBuilder(s.ctx, squint.NewBuilder(squint.BindAt()))
+
+		s.mock.ExpectQuery("select id from junk where id = @p1").WithArgs(10).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(10),
+		)
+
+		var id int
+		s.Nil(s.db.QueryRowContext(ctx, "select id from junk where id =", 10).Scan(&id))
+		s.Equal(10, id)
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("wrap", func() {
+		sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		s.Require().Nil(err)
+
+		db := driver.Wrap(sqlDB)
+		defer db.Close()
+
+		ctx := driver.WithOptions(s.ctx, squint.BindColon())
+
+		mock.ExpectExec("delete from junk where id = :b1").WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err = db.ExecContext(ctx, "delete from junk where id =", 10)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+}
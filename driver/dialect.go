@@ -0,0 +1,40 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/mwblythe/squint"
+)
+
+// bindStyles are the bind placeholder styles of well-known drivers.
+// Since squint ties its dialect to the bind style, these also select
+// things like paging syntax.
+var bindStyles = map[string]func() squint.Option{
+	"mysql":     squint.BindQuestion,
+	"sqlite":    squint.BindQuestion,
+	"sqlite3":   squint.BindQuestion,
+	"postgres":  squint.BindDollar,
+	"pgx":       squint.BindDollar,
+	"sqlserver": squint.BindAt,
+	"mssql":     squint.BindAt,
+	"oracle":    squint.BindColon,
+	"godror":    squint.BindColon,
+}
+
+// resolveStyle settles the bind placeholder style for the driver. An
+// explicit Placeholder wins, then a bind option set on the Builder, then
+// the style for a well-known driver name.
+func (d *sqDriver) resolveStyle() error {
+	if d.style == nil && !d.builder.HasBind() {
+		style, ok := bindStyles[d.toDriver]
+		if !ok {
+			return fmt.Errorf("unknown bind style for driver %q: use Placeholder() or a Builder with a bind option", d.toDriver)
+		}
+
+		d.style = style()
+	}
+
+	d.builder.style = d.style
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package driver_test
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestPlaceholder() {
+	dsn := "placeholder-tests"
+
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().Nil(err)
+
+	defer mockDB.Close()
+
+	// pose as a well-known driver
+	sql.Register("pgx", mockDB.Driver())
+
+	check := func(db *sql.DB, query string) {
+		mock.ExpectExec(query).WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := db.ExecContext(s.ctx, "delete from junk where id =", 10)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+	}
+
+	s.Run("inferred", func() {
+		s.Require().Nil(driver.Register("pgx"))
+
+		db, err := sql.Open("squint-pgx", dsn)
+		s.Require().Nil(err)
+
+		defer db.Close()
+
+		check(db, "delete from junk where id = $1")
+	})
+
+	s.Run("override", func() {
+		c, err := driver.NewConnector("pgx", driver.DSN(dsn), driver.Placeholder(squint.BindAt()))
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		check(db, "delete from junk where id = @p1")
+	})
+
+	s.Run("builder", func() {
+		c, err := driver.NewConnector("pgx", driver.DSN(dsn), driver.Builder(squint.NewBuilder(squint.BindAt())))
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		check(db, "delete from junk where id = @p1")
+	})
+
+	s.Run("builder without bind", func() {
+		c, err := driver.NewConnector("pgx", driver.DSN(dsn), driver.Builder(squint.NewBuilder(squint.OmitEmpty())))
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		check(db, "delete from junk where id = $1")
+	})
+
+	s.Run("builder override", func() {
+		c, err := driver.NewConnector("pgx",
+			driver.DSN(dsn),
+			driver.Builder(squint.NewBuilder(squint.BindAt())),
+			driver.Placeholder(squint.BindColon()),
+		)
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		check(db, "delete from junk where id = :b1")
+	})
+
+	s.Run("unknown", func() {
+		s.NotNil(driver.Register("nosuchdb"))
+
+		c, err := driver.NewConnector("nosuchdb")
+		s.NotNil(err)
+		s.Nil(c)
+
+		c, err = driver.NewConnector("nosuchdb", driver.Builder(squint.NewBuilder()))
+		s.NotNil(err)
+		s.Nil(c)
+	})
+
+	s.Run("unknown with style", func() {
+		s.Nil(driver.Register("sqlmock",
+			driver.Name("squint-placeholder"),
+			driver.Placeholder(squint.BindColon()),
+		))
+
+		db, err := sql.Open("squint-placeholder", dsn)
+		s.Require().Nil(err)
+
+		defer db.Close()
+
+		check(db, "delete from junk where id = :b1")
+	})
+}
@@ -14,11 +14,14 @@ import (
 
 // compile-time interface checks
 var (
-	_ driver.Driver = (*sqDriver)(nil)
+	_ driver.Driver        = (*sqDriver)(nil)
+	_ driver.DriverContext = (*sqDriver)(nil)
 )
 
 var ErrNoDB = errors.New("no database connection")
 
+var errNotSquint = errors.New("not a squint driver")
+
 // Register a sql driver to produce a squint-enabled version.
 //
 // toDriver is the original sql driver, e.g. "mysql"
@@ -27,83 +30,313 @@ var ErrNoDB = errors.New("no database connection")
 //
 // Name(string) : name to use for the squint driver. (Default "squint-" + toDriver)
 // Builder(*Builder) : squint Builder to use. (Default is Builder with no options)
-func Register(toDriver string, o ...Option) {
-	var drv sqDriver
+// Placeholder(squint.Option) : bind placeholder style. (Default is inferred from toDriver)
+// BeforeQuery(BeforeFunc), OnQuery(AfterFunc) : query hooks
+// SlowQuery(time.Duration), ExplainSlow(string) : slow query logging
+// Replicas(...string) : read replica DSNs
+//
+// Unless Placeholder is given, or a Builder with its own bind option,
+// the placeholder style is inferred for well-known drivers, such as
+// "postgres" or "mysql". For other drivers, an error is returned.
+// Placeholder overrides the bind option of a Builder.
+func Register(toDriver string, o ...Option) error {
+	drv := newDriver(Name("squint-" + toDriver))
 	drv.toDriver = toDriver
+	drv.set(o...)
+
+	if err := drv.resolveStyle(); err != nil {
+		return err
+	}
+
+	sql.Register(drv.name, drv)
+
+	return nil
+}
+
+// sqDriver is the squint proxy driver
+type sqDriver struct {
+	name        string
+	toDriver    string
+	dsn         string
+	builder     *builder
+	style       squint.Option // bind placeholder style
+	before      []BeforeFunc
+	after       []AfterFunc
+	slow        time.Duration // slow query threshold
+	explainSlow bool          // run EXPLAIN for slow queries
+	explain     string        // EXPLAIN prefix
+	replicas    []string      // replica DSNs
+	next        uint32        // next replica
+	mu          sync.Mutex
+	pools       map[string]*innerPool // inner pools by DSN
+}
+
+// innerPool is an inner pool, shared by the connectors using its DSN
+type innerPool struct {
+	db   *sql.DB
+	refs int
+}
+
+// newDriver returns a squint driver with the given options
+func newDriver(o ...Option) *sqDriver {
+	var drv sqDriver
 
 	// defaults
-	drv.set(
-		Name("squint-"+toDriver),
-		Builder(squint.NewBuilder()),
-	)
+	drv.builder = newBuilder(squint.NewBuilder())
 
 	// overrides
 	drv.set(o...)
 
-	sql.Register(drv.name, &drv)
+	return &drv
 }
 
-// sqDriver is the squint proxy driver
-type sqDriver struct {
-	name     string
-	toDriver string
-	builder  *builder
-	db       sync.Map
+// Open returns a connection that holds its own reference to the inner
+// pools, released when the connection is closed
+func (d *sqDriver) Open(dsn string) (driver.Conn, error) {
+	dsns := append([]string{dsn}, d.replicas...)
+
+	db, err := d.acquire(dsns...)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		_ = d.release(dsns...)
+		return nil, err
+	}
+
+	c := newConn(conn, d, db)
+	c.release = func() error { return d.release(dsns...) }
+
+	return c, nil
 }
 
-func (d *sqDriver) Open(dsn string) (c driver.Conn, err error) {
-	db, err := d.pool(dsn)
+func (d *sqDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	dsns := append([]string{dsn}, d.replicas...)
+
+	db, err := d.acquire(dsns...)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	if db == nil {
-		err = ErrNoDB
-		return
+		_ = d.release(dsns...)
+		return nil, ErrNoDB
 	}
 
-	// build conn
-	conn, err := db.Conn(context.Background())
-	if err == nil && conn != nil {
-		c = newConn(conn, d.builder, dsn)
+	return &sqConnector{drv: d, db: db, dsns: dsns}, nil
+}
+
+// pool returns the inner pool for a DSN, opening it if needed
+func (d *sqDriver) pool(dsn string) (*sql.DB, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, err := d.lockedPool(dsn)
+	if err != nil {
+		return nil, err
 	}
 
-	return
+	return p.db, nil
 }
 
-func (d *sqDriver) pool(dsn string) (db *sql.DB, err error) {
-	if v, ok := d.db.Load(dsn); ok {
-		db = v.(*sql.DB)
-	} else {
-		db, err = sql.Open(d.toDriver, dsn)
-		if err == nil {
-			d.db.Store(dsn, db)
+// acquire takes a reference to the inner pools for the DSNs, opening
+// them if needed, and returns the first
+func (d *sqDriver) acquire(dsns ...string) (*sql.DB, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pools := make([]*innerPool, 0, len(dsns))
+
+	for _, dsn := range dsns {
+		p, err := d.lockedPool(dsn)
+		if err != nil {
+			return nil, err
 		}
+
+		pools = append(pools, p)
 	}
 
-	return
+	for _, p := range pools {
+		p.refs++
+	}
+
+	return pools[0].db, nil
 }
 
-func SetConnMaxIdleTime(outerDB *sql.DB, d time.Duration) {
-	inDB, err := innerDB(outerDB)
-	if err == nil && inDB != nil {
-		outerDB.SetConnMaxIdleTime(d)
-		inDB.SetConnMaxIdleTime(d)
+// release drops a reference to the inner pools for the DSNs,
+// closing any that are no longer used
+func (d *sqDriver) release(dsns ...string) (err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, dsn := range dsns {
+		p, ok := d.pools[dsn]
+		if !ok {
+			continue
+		}
+
+		if p.refs--; p.refs > 0 {
+			continue
+		}
+
+		delete(d.pools, dsn)
+
+		if cerr := p.db.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// lockedPool returns the inner pool for a DSN, opening it if needed.
+// The caller must hold the lock.
+func (d *sqDriver) lockedPool(dsn string) (*innerPool, error) {
+	if p, ok := d.pools[dsn]; ok {
+		return p, nil
+	}
+
+	db, err := sql.Open(d.toDriver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pools == nil {
+		d.pools = make(map[string]*innerPool)
+	}
+
+	p := &innerPool{db: db}
+	d.pools[dsn] = p
+
+	return p, nil
+}
+
+// SetConnMaxIdleTime sets the maximum idle time of connections
+// on the outer and all inner pools
+func SetConnMaxIdleTime(outerDB *sql.DB, d time.Duration) error {
+	return setPools(outerDB, func(db *sql.DB) { db.SetConnMaxIdleTime(d) })
+}
+
+// SetConnMaxLifetime sets the maximum lifetime of connections
+// on the outer and all inner pools
+func SetConnMaxLifetime(outerDB *sql.DB, d time.Duration) error {
+	return setPools(outerDB, func(db *sql.DB) { db.SetConnMaxLifetime(d) })
+}
+
+// SetMaxOpenConns sets the maximum number of open connections
+// on the outer and all inner pools
+func SetMaxOpenConns(outerDB *sql.DB, n int) error {
+	return setPools(outerDB, func(db *sql.DB) { db.SetMaxOpenConns(n) })
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections
+// on the outer and all inner pools
+func SetMaxIdleConns(outerDB *sql.DB, n int) error {
+	return setPools(outerDB, func(db *sql.DB) { db.SetMaxIdleConns(n) })
+}
+
+// setPools applies a setting to the outer pool, then to the inner pools.
+// Finding the inner pools needs a connection from the outer; if that
+// fails, the error is returned and only the outer pool is set.
+func setPools(outerDB *sql.DB, set func(*sql.DB)) error {
+	set(outerDB)
+
+	inDBs, err := innerDBs(outerDB)
+	if err != nil {
+		return err
+	}
+
+	for _, inDB := range inDBs {
+		set(inDB)
 	}
+
+	return nil
 }
 
-func SetConnMaxLifetime(outerDB *sql.DB, d time.Duration) {
+// PoolStats holds the statistics of the outer and inner pools
+type PoolStats struct {
+	Outer    sql.DBStats
+	Inner    sql.DBStats
+	Replicas []sql.DBStats
+}
+
+// Stats returns the statistics of the outer and inner pools, including
+// any replicas. Note that finding the inner pool needs a connection
+// from the outer.
+func Stats(outerDB *sql.DB) (PoolStats, error) {
+	var stats PoolStats
+
+	inDBs, err := innerDBs(outerDB)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.Outer = outerDB.Stats()
+	stats.Inner = inDBs[0].Stats()
+
+	for _, inDB := range inDBs[1:] {
+		stats.Replicas = append(stats.Replicas, inDB.Stats())
+	}
+
+	return stats, nil
+}
+
+// Close closes the outer pool and releases its inner pools. An inner
+// pool is shared by outer pools with the same driver and DSN (or replica),
+// and is closed along with the last of them.
+//
+// Before Go 1.17, sql.DB does not release the inner pools itself, so use
+// this instead of its Close method. Finding them needs a connection from
+// the outer pool.
+func Close(outerDB *sql.DB) error {
+	c, err := rawConn(outerDB)
+	if err == errNotSquint {
+		return err
+	}
+
+	if cerr := outerDB.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+
+	if c != nil && c.connector != nil {
+		if cerr := c.connector.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// innerDBs returns the inner pools: the primary, then any replicas
+func innerDBs(outerDB *sql.DB) ([]*sql.DB, error) {
 	inDB, err := innerDB(outerDB)
-	if err == nil && inDB != nil {
-		outerDB.SetConnMaxLifetime(d)
-		inDB.SetConnMaxLifetime(d)
+	if err != nil {
+		return nil, err
 	}
+
+	replicas, err := outerDB.Driver().(*sqDriver).replicaPools()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*sql.DB{inDB}, replicas...), nil
 }
 
 func innerDB(outerDB *sql.DB) (*sql.DB, error) {
-	driver, ok := outerDB.Driver().(*sqDriver)
-	if !ok {
-		return nil, errors.New("not a squint driver")
+	c, err := rawConn(outerDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.db, nil
+}
+
+// rawConn returns the squint connection behind one from the outer pool
+func rawConn(outerDB *sql.DB) (*sqConn, error) {
+	if _, ok := outerDB.Driver().(*sqDriver); !ok {
+		return nil, errNotSquint
 	}
 
 	conn, err := outerDB.Conn(context.Background())
@@ -112,16 +345,16 @@ func innerDB(outerDB *sql.DB) (*sql.DB, error) {
 	}
 	defer conn.Close()
 
-	var outDB *sql.DB
+	var c *sqConn
 	err = conn.Raw(func(driverConn interface{}) error {
-		sqConn, ok := driverConn.(*sqConn)
+		sc, ok := driverConn.(*sqConn)
 		if !ok {
 			return errors.New("not a squint connection")
 		}
 
-		outDB, err = driver.pool(sqConn.dsn)
-		return err
+		c = sc
+		return nil
 	})
 
-	return outDB, err
+	return c, err
 }
@@ -49,7 +49,7 @@ func (s *DriverSuite) SetupSuite() {
 
 	s.ctx = context.Background()
 	s.mock = mock
-	s.builder = squint.NewBuilder()
+	s.builder = squint.NewBuilder(squint.BindQuestion())
 
 	driver.Register("sqlmock", driver.Builder(s.builder))
 
@@ -164,7 +164,16 @@ func (s *DriverSuite) getValues(in Bits) (out []sqldriver.Value) {
 }
 
 func (s *DriverSuite) TearDownSuite() {
+	// closing the last use of the inner pool closes its connections
+	stats, err := driver.Stats(s.db)
+	s.Require().Nil(err)
+
+	for i := 0; i < stats.Inner.OpenConnections; i++ {
+		s.mock.ExpectClose()
+	}
+
 	s.Nil(s.db.Close())
+	s.Nil(s.mock.ExpectationsWereMet())
 }
 
 // Note that these deprecated driver functions are impossible to trigger
@@ -205,6 +214,7 @@ func (s *DriverSuite) TestZDeprecated() {
 		rows, err := st.Query([]sqldriver.Value{10}) // nolint
 		s.Nil(err)
 		s.NotNil(rows)
+		s.Nil(rows.Close())
 
 		s.Nil(s.mock.ExpectationsWereMet())
 	})
@@ -221,4 +231,26 @@ func (s *DriverSuite) TestZDeprecated() {
 		s.Nil(err)
 		s.Nil(s.mock.ExpectationsWereMet())
 	})
+
+	s.Nil(con.Close())
+}
+
+func (s *DriverSuite) TestNamed() {
+	id := sql.Named("id", 5)
+
+	s.Run("native", func() {
+		ctx := driver.WithOptions(s.ctx, squint.BindAt())
+
+		s.mock.ExpectExec("delete from junk where id = @id or parent = @id").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := s.db.ExecContext(ctx, "delete from junk where id =", id, "or parent =", id)
+		s.Nil(err)
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("positional", func() {
+		s.mock.ExpectExec("delete from junk where id = ?").WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := s.db.ExecContext(s.ctx, "delete from junk where id =", id)
+		s.Nil(err)
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
 }
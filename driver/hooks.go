@@ -0,0 +1,53 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// QueryInfo describes a query run by the squint driver
+type QueryInfo struct {
+	Bits         []interface{} // original query and binds
+	SQL          string        // built SQL
+	Binds        []interface{} // built binds
+	Duration     time.Duration // time to run, which excludes reading rows
+	RowsAffected int64         // rows affected, or -1 for a query or if unknown
+	Err          error         // error, if any
+}
+
+// BeforeFunc is a hook called before a query is run.
+// Duration, RowsAffected and Err are not yet set.
+type BeforeFunc func(ctx context.Context, info QueryInfo) context.Context
+
+// AfterFunc is a hook called after a query is run
+type AfterFunc func(ctx context.Context, info QueryInfo)
+
+// newInfo builds a query, returning its details
+func (d *sqDriver) newInfo(ctx context.Context, query string, args []driver.NamedValue) QueryInfo {
+	info := QueryInfo{
+		Bits:         namedBits(query, args),
+		RowsAffected: -1,
+	}
+
+	info.SQL, info.Binds = d.builder.build(ctx, info.Bits)
+
+	return info
+}
+
+// observe runs a query between the before and after hooks
+func (d *sqDriver) observe(ctx context.Context, info *QueryInfo, run func(context.Context) error) error {
+	for _, fn := range d.before {
+		ctx = fn(ctx, *info)
+	}
+
+	start := time.Now()
+	info.Err = run(ctx)
+	info.Duration = time.Since(start)
+
+	for _, fn := range d.after {
+		fn(ctx, *info)
+	}
+
+	return info.Err
+}
@@ -0,0 +1,97 @@
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+type hookKey struct{}
+
+func (s *DriverSuite) TestHooks() {
+	dsn := "hook-tests"
+
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().Nil(err)
+
+	defer mockDB.Close()
+
+	var before, after []driver.QueryInfo
+
+	var traced []interface{}
+
+	c, err := driver.NewConnector(
+		mockConnector{mockDB.Driver(), dsn},
+		driver.Placeholder(squint.BindQuestion()),
+		driver.BeforeQuery(func(ctx context.Context, info driver.QueryInfo) context.Context {
+			before = append(before, info)
+			return context.WithValue(ctx, hookKey{}, "span")
+		}),
+		driver.OnQuery(func(ctx context.Context, info driver.QueryInfo) {
+			after = append(after, info)
+			traced = append(traced, ctx.Value(hookKey{}))
+		}),
+	)
+	s.Require().Nil(err)
+
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	reset := func() {
+		before, after, traced = nil, nil, nil
+	}
+
+	s.Run("exec", func() {
+		reset()
+
+		mock.ExpectExec("delete from junk where id IN ( ?, ? )").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+		_, err := db.ExecContext(s.ctx, "delete from junk where id IN", []int{1, 2})
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+
+		s.Require().Len(before, 1)
+		s.Equal(Bits{"delete from junk where id IN", []int{1, 2}}, Bits(before[0].Bits))
+		s.Equal("delete from junk where id IN ( ?, ? )", before[0].SQL)
+		s.Equal(Bits{1, 2}, Bits(before[0].Binds))
+
+		s.Require().Len(after, 1)
+		s.Equal(int64(2), after[0].RowsAffected)
+		s.Nil(after[0].Err)
+		s.Equal([]interface{}{"span"}, traced)
+	})
+
+	s.Run("query", func() {
+		reset()
+
+		mock.ExpectQuery("select id from junk where id = ?").WithArgs(1).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1),
+		)
+
+		var id int
+		s.Nil(db.QueryRowContext(s.ctx, "select id from junk where id =", 1).Scan(&id))
+		s.Nil(mock.ExpectationsWereMet())
+
+		s.Require().Len(after, 1)
+		s.Equal("select id from junk where id = ?", after[0].SQL)
+		s.Equal(int64(-1), after[0].RowsAffected)
+		s.Nil(after[0].Err)
+	})
+
+	s.Run("error", func() {
+		reset()
+
+		oops := errors.New("oops")
+		mock.ExpectExec("delete from junk").WillReturnError(oops)
+		_, err := db.ExecContext(s.ctx, "delete from junk")
+		s.Equal(oops, err)
+		s.Nil(mock.ExpectationsWereMet())
+
+		s.Require().Len(after, 1)
+		s.Equal(oops, after[0].Err)
+		s.Equal(int64(-1), after[0].RowsAffected)
+	})
+}
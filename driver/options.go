@@ -1,6 +1,8 @@
 package driver
 
 import (
+	"time"
+
 	"github.com/mwblythe/squint"
 )
 
@@ -26,3 +28,68 @@ func Name(name string) Option {
 		d.name = name
 	}
 }
+
+// DSN is the data source name for NewConnector, when connecting by driver name
+func DSN(dsn string) Option {
+	return func(d *sqDriver) {
+		d.dsn = dsn
+	}
+}
+
+// Placeholder is the bind placeholder style to use, such as squint.BindDollar().
+// This overrides the style inferred from the original driver name, and
+// the bind option of the Builder.
+func Placeholder(style squint.Option) Option {
+	return func(d *sqDriver) {
+		d.style = style
+	}
+}
+
+// BeforeQuery adds a hook that is called before each query is run.
+// The context it returns is used for the query and the OnQuery hooks,
+// so it may carry things like a tracing span.
+func BeforeQuery(fn BeforeFunc) Option {
+	return func(d *sqDriver) {
+		d.before = append(d.before, fn)
+	}
+}
+
+// OnQuery adds a hook that is called after each query is run,
+// with its timing, rows affected and error.
+func OnQuery(fn AfterFunc) Option {
+	return func(d *sqDriver) {
+		d.after = append(d.after, fn)
+	}
+}
+
+// SlowQuery logs any query that takes at least the threshold to run,
+// along with its fingerprint.
+func SlowQuery(threshold time.Duration) Option {
+	return func(d *sqDriver) {
+		d.slow = threshold
+	}
+}
+
+// ExplainSlow runs EXPLAIN for slow reads and includes the plan in the
+// log. Writes are never explained. The prefix is prepended to the query,
+// e.g. "EXPLAIN ANALYZE". If empty, the default for the driver is used,
+// and there is none for sqlserver or oracle drivers.
+func ExplainSlow(prefix string) Option {
+	return func(d *sqDriver) {
+		d.explainSlow = true
+		d.explain = prefix
+	}
+}
+
+// Replicas are DSNs of read replicas. SELECT statements outside of a
+// transaction are sent to them, round robin, and all others go to the
+// primary DSN. Use UsePrimary to override this for a query.
+//
+// The replicas belong to the registered driver, so every DSN opened with
+// it uses them as its primary's replicas. For more than one primary,
+// register the driver under another Name for each, or use NewConnector.
+func Replicas(dsns ...string) Option {
+	return func(d *sqDriver) {
+		d.replicas = append(d.replicas, dsns...)
+	}
+}
@@ -0,0 +1,92 @@
+package driver_test
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestPool() {
+	dsn := "pool-tests"
+
+	_, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.MonitorPingsOption(true))
+	s.Require().Nil(err)
+
+	db, err := sql.Open("squint-sqlmock", dsn)
+	s.Require().Nil(err)
+
+	s.Nil(driver.SetMaxOpenConns(db, 5))
+	s.Nil(driver.SetMaxIdleConns(db, 2))
+	s.Nil(driver.SetConnMaxIdleTime(db, time.Minute))
+	s.Nil(driver.SetConnMaxLifetime(db, time.Hour))
+
+	stats, err := driver.Stats(db)
+	s.Require().Nil(err)
+	s.Equal(5, stats.Outer.MaxOpenConnections)
+	s.Equal(5, stats.Inner.MaxOpenConnections)
+	s.Equal(1, stats.Outer.OpenConnections)
+	s.Equal(1, stats.Inner.OpenConnections)
+
+	mock.ExpectClose()
+	s.Nil(driver.Close(db))
+	s.Nil(mock.ExpectationsWereMet())
+
+	_, err = driver.Stats(db)
+	s.NotNil(err)
+
+	// not a squint driver
+	plain, _, err := sqlmock.New()
+	s.Require().Nil(err)
+
+	defer plain.Close()
+	s.NotNil(driver.Close(plain))
+
+	// the outer pool is set, even if the inner can't be found
+	s.NotNil(driver.SetMaxOpenConns(plain, 3))
+	s.Equal(3, plain.Stats().MaxOpenConnections)
+}
+
+func (s *DriverSuite) TestPoolShared() {
+	dsn := "pool-shared-tests"
+
+	_, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.MonitorPingsOption(true))
+	s.Require().Nil(err)
+
+	db1, err := sql.Open("squint-sqlmock", dsn)
+	s.Require().Nil(err)
+
+	db2, err := sql.Open("squint-sqlmock", dsn)
+	s.Require().Nil(err)
+
+	mock.ExpectPing()
+	s.Nil(db1.PingContext(s.ctx))
+
+	// the inner pool is still used by db2, and is released only once,
+	// though both driver.Close and sql.DB release it
+	s.Nil(driver.Close(db1))
+
+	mock.ExpectPing()
+	s.Nil(db2.PingContext(s.ctx))
+
+	// plain Close releases the last use, closing the inner pool
+	mock.ExpectClose()
+	s.Nil(db2.Close())
+	s.Nil(mock.ExpectationsWereMet())
+}
+
+func (s *DriverSuite) TestPoolOpen() {
+	dsn := "pool-open-tests"
+
+	_, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.MonitorPingsOption(true))
+	s.Require().Nil(err)
+
+	con, err := s.db.Driver().Open(dsn)
+	s.Require().Nil(err)
+
+	// closing the connection releases its use of the inner pool
+	mock.ExpectClose()
+	s.Nil(con.Close())
+	s.Nil(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,70 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// patterns used to classify statements
+var (
+	readRX   = regexp.MustCompile(`(?i)^\s*(SELECT|WITH)\b`)
+	writeRX  = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE)\b`)
+	selectRX = regexp.MustCompile(`(?i)^\s*SELECT\b`)
+	lockRX   = regexp.MustCompile(`(?i)` + strings.Join([]string{
+		`\bFOR\s+(UPDATE|SHARE|NO\s+KEY\s+UPDATE|KEY\s+SHARE)\b`, // postgres, mysql, oracle
+		`\bLOCK\s+IN\s+SHARE\s+MODE\b`,                           // mysql
+		`\bWITH\s*\([^)]*\b(UPDLOCK|XLOCK|HOLDLOCK|TABLOCKX?|PAGLOCK|SERIALIZABLE|REPEATABLEREAD|READCOMMITTEDLOCK)\b`, // sqlserver hints
+	}, "|"))
+)
+
+// queryer runs queries, either on a connection or a pool
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// isRead reports whether a built statement only reads, and may run on
+// a replica. That is a SELECT without locking, or a WITH that doesn't write.
+// Locking covers FOR UPDATE/SHARE, LOCK IN SHARE MODE (mysql) and
+// locking table hints such as WITH (UPDLOCK) (sqlserver).
+func isRead(query string) bool {
+	if !readRX.MatchString(query) || lockRX.MatchString(query) {
+		return false
+	}
+
+	return selectRX.MatchString(query) || !writeRX.MatchString(query)
+}
+
+// replica returns the next replica pool, round robin
+func (d *sqDriver) replica() (*sql.DB, error) {
+	n := atomic.AddUint32(&d.next, 1)
+	return d.pool(d.replicas[n%uint32(len(d.replicas))])
+}
+
+// replicaPools returns the pools of all replicas
+func (d *sqDriver) replicaPools() ([]*sql.DB, error) {
+	dbs := make([]*sql.DB, 0, len(d.replicas))
+
+	for _, dsn := range d.replicas {
+		db, err := d.pool(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		dbs = append(dbs, db)
+	}
+
+	return dbs, nil
+}
+
+// reader returns where to run a query: a replica for a read outside
+// of a transaction, otherwise the primary connection
+func (c *sqConn) reader(ctx context.Context, query string) (queryer, error) {
+	if len(c.drv.replicas) == 0 || c.inTx || usePrimary(ctx) || !isRead(query) {
+		return c.conn, nil
+	}
+
+	return c.drv.replica()
+}
@@ -0,0 +1,132 @@
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestReplicas() {
+	newMock := func(dsn string) sqlmock.Sqlmock {
+		db, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		s.Require().Nil(err)
+		s.T().Cleanup(func() { db.Close() })
+
+		return mock
+	}
+
+	primary := newMock("primary-tests")
+	replica1 := newMock("replica1-tests")
+	replica2 := newMock("replica2-tests")
+
+	c, err := driver.NewConnector("sqlmock",
+		driver.DSN("primary-tests"),
+		driver.Replicas("replica1-tests", "replica2-tests"),
+		driver.Placeholder(squint.BindQuestion()),
+	)
+	s.Require().Nil(err)
+
+	db := sql.OpenDB(c)
+	defer driver.Close(db)
+
+	expectRead := func(mock sqlmock.Sqlmock, query string) {
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	}
+
+	read := func(ctx context.Context, query string, more ...interface{}) {
+		var id int
+		s.Nil(db.QueryRowContext(ctx, query, append([]interface{}{1}, more...)...).Scan(&id))
+	}
+
+	met := func() {
+		s.Nil(primary.ExpectationsWereMet())
+		s.Nil(replica1.ExpectationsWereMet())
+		s.Nil(replica2.ExpectationsWereMet())
+	}
+
+	s.Run("round robin", func() {
+		expectRead(replica2, "select id from junk where id = ?")
+		expectRead(replica1, "select id from junk where id = ?")
+		expectRead(replica2, "select id from junk where id = ?")
+
+		for i := 0; i < 3; i++ {
+			read(s.ctx, "select id from junk where id =")
+		}
+
+		met()
+	})
+
+	s.Run("write", func() {
+		primary.ExpectExec("delete from junk where id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := db.ExecContext(s.ctx, "delete from junk where id =", 1)
+		s.Nil(err)
+		met()
+	})
+
+	s.Run("locking", func() {
+		expectRead(primary, "select id from junk where id = ? for update")
+		read(s.ctx, "select id from junk where id =", "for update")
+		met()
+
+		expectRead(primary, "select id from junk where id = ? lock in share mode")
+		read(s.ctx, "select id from junk where id =", "lock in share mode")
+		met()
+
+		expectRead(primary, "select id from junk with (updlock, rowlock) where id = ?")
+		read(s.ctx, "select id from junk with (updlock, rowlock) where id =")
+		met()
+
+		expectRead(primary, "select id from junk WITH (HOLDLOCK) where id = ?")
+		read(s.ctx, "select id from junk WITH (HOLDLOCK) where id =")
+		met()
+
+		// other hints are fine
+		expectRead(replica1, "select id from junk with (nolock) where id = ?")
+		read(s.ctx, "select id from junk with (nolock) where id =")
+		met()
+	})
+
+	s.Run("use primary", func() {
+		expectRead(primary, "select id from junk where id = ?")
+		read(driver.UsePrimary(s.ctx), "select id from junk where id =")
+		met()
+	})
+
+	s.Run("transaction", func() {
+		primary.ExpectBegin()
+		expectRead(primary, "select id from junk where id = ?")
+		primary.ExpectCommit()
+
+		tx, err := db.BeginTx(s.ctx, nil)
+		s.Require().Nil(err)
+
+		var id int
+		s.Nil(tx.QueryRowContext(s.ctx, "select id from junk where id =", 1).Scan(&id))
+		s.Nil(tx.Commit())
+		met()
+
+		// back to replicas after the transaction
+		expectRead(replica2, "select id from junk where id = ?")
+		read(s.ctx, "select id from junk where id =")
+		met()
+	})
+
+	s.Run("stats", func() {
+		stats, err := driver.Stats(db)
+		s.Nil(err)
+		s.Len(stats.Replicas, 2)
+	})
+
+	s.Run("connector", func() {
+		c, err := driver.NewConnector(
+			mockConnector{},
+			driver.Placeholder(squint.BindQuestion()),
+			driver.Replicas("replica1-tests"),
+		)
+		s.NotNil(err)
+		s.Nil(c)
+	})
+}
@@ -4,24 +4,42 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"io"
+	"reflect"
 )
 
 // compile-time interface checks
 var (
-	_ driver.Rows = (*sqRows)(nil)
+	_ driver.Rows                           = (*sqRows)(nil)
+	_ driver.RowsNextResultSet              = (*sqRows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*sqRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*sqRows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*sqRows)(nil)
+	_ driver.RowsColumnTypeLength           = (*sqRows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*sqRows)(nil)
 )
 
 // sqRows is a sql.Rows wrapper to implement the driver.Rows interface
 type sqRows struct {
 	*sql.Rows
+	onClose func()            // called once the rows are closed
+	types   []*sql.ColumnType // column types of the current result set
 }
 
-func (r sqRows) Columns() []string {
+func (r *sqRows) Close() error {
+	err := r.Rows.Close()
+	if r.onClose != nil {
+		r.onClose()
+	}
+
+	return err
+}
+
+func (r *sqRows) Columns() []string {
 	c, _ := r.Rows.Columns()
 	return c
 }
 
-func (r sqRows) Next(dest []driver.Value) error {
+func (r *sqRows) Next(dest []driver.Value) error {
 	if !r.Rows.Next() {
 		err := r.Rows.Err()
 		if err == nil {
@@ -46,3 +64,77 @@ func (r sqRows) Next(dest []driver.Value) error {
 
 	return err
 }
+
+func (r *sqRows) HasNextResultSet() bool {
+	// inner rows close themselves when done, unless there are more result sets
+	_, err := r.Rows.Columns()
+	return err == nil
+}
+
+func (r *sqRows) NextResultSet() error {
+	r.types = nil
+
+	if !r.Rows.NextResultSet() {
+		err := r.Rows.Err()
+		if err == nil {
+			err = io.EOF
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct := r.columnType(index); ct != nil {
+		return ct.ScanType()
+	}
+
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *sqRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct := r.columnType(index); ct != nil {
+		return ct.DatabaseTypeName()
+	}
+
+	return ""
+}
+
+func (r *sqRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if ct := r.columnType(index); ct != nil {
+		return ct.Nullable()
+	}
+
+	return false, false
+}
+
+func (r *sqRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	if ct := r.columnType(index); ct != nil {
+		return ct.Length()
+	}
+
+	return 0, false
+}
+
+func (r *sqRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	if ct := r.columnType(index); ct != nil {
+		return ct.DecimalSize()
+	}
+
+	return 0, 0, false
+}
+
+// columnType returns the inner column type at an index, if available
+func (r *sqRows) columnType(index int) *sql.ColumnType {
+	if r.types == nil {
+		r.types, _ = r.Rows.ColumnTypes()
+	}
+
+	if index < 0 || index >= len(r.types) {
+		return nil
+	}
+
+	return r.types[index]
+}
@@ -0,0 +1,99 @@
+package driver_test
+
+import (
+	"reflect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func (s *DriverSuite) TestColumnTypes() {
+	s.mock.ExpectQuery("select id, name, price from junk").WillReturnRows(
+		sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("INT", int64(0)).Nullable(false),
+			sqlmock.NewColumn("name").OfType("VARCHAR", "").WithLength(50).Nullable(true),
+			sqlmock.NewColumn("price").OfType("DECIMAL", 0.0).WithPrecisionAndScale(10, 2),
+		).AddRow(1, "widget", 9.99),
+	)
+
+	rows, err := s.db.QueryContext(s.ctx, "select id, name, price from junk")
+	s.Require().Nil(err)
+
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	s.Require().Nil(err)
+	s.Require().Len(types, 3)
+
+	s.Equal("INT", types[0].DatabaseTypeName())
+	s.Equal(reflect.TypeOf(int64(0)), types[0].ScanType())
+
+	nullable, ok := types[0].Nullable()
+	s.True(ok)
+	s.False(nullable)
+
+	length, ok := types[1].Length()
+	s.True(ok)
+	s.Equal(int64(50), length)
+
+	nullable, ok = types[1].Nullable()
+	s.True(ok)
+	s.True(nullable)
+
+	precision, scale, ok := types[2].DecimalSize()
+	s.True(ok)
+	s.Equal(int64(10), precision)
+	s.Equal(int64(2), scale)
+
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *DriverSuite) TestNextResultSet() {
+	s.Run("multiple", func() {
+		s.mock.ExpectQuery("select id from junk; select name from junk").WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1),
+			sqlmock.NewRows([]string{"name"}).AddRow("widget"),
+		)
+
+		rows, err := s.db.QueryContext(s.ctx, "select id from junk; select name from junk")
+		s.Require().Nil(err)
+
+		defer rows.Close()
+
+		var id int
+
+		s.True(rows.Next())
+		s.Nil(rows.Scan(&id))
+		s.Equal(1, id)
+		s.False(rows.Next())
+
+		var name string
+
+		s.Require().True(rows.NextResultSet())
+		s.True(rows.Next())
+		s.Nil(rows.Scan(&name))
+		s.Equal("widget", name)
+		s.False(rows.Next())
+
+		s.False(rows.NextResultSet())
+		s.Nil(rows.Err())
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("single", func() {
+		s.mock.ExpectQuery("select id from junk").WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1),
+		)
+
+		rows, err := s.db.QueryContext(s.ctx, "select id from junk")
+		s.Require().Nil(err)
+
+		defer rows.Close()
+
+		s.True(rows.Next())
+		s.False(rows.Next())
+
+		s.False(rows.NextResultSet())
+		s.Nil(rows.Err())
+		s.Nil(s.mock.ExpectationsWereMet())
+	})
+}
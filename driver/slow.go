@@ -0,0 +1,118 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// explainPrefixes are the EXPLAIN prefixes of drivers that differ
+// from the default. An empty prefix means there is no EXPLAIN that
+// returns the plan as rows, e.g. SHOWPLAN or EXPLAIN PLAN FOR.
+var explainPrefixes = map[string]string{
+	"sqlite":    "EXPLAIN QUERY PLAN",
+	"sqlite3":   "EXPLAIN QUERY PLAN",
+	"sqlserver": "",
+	"mssql":     "",
+	"oracle":    "",
+	"godror":    "",
+}
+
+// patterns used to normalize SQL for fingerprints
+var (
+	fpLiteralRX = regexp.MustCompile(`'(?:[^']|'')*'|\$\d+|@p\d+|:b\d+|\b\d+(?:\.\d+)?\b`)
+	fpListRX    = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+	fpSpaceRX   = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint returns a short hash identifying the shape of a query.
+// Binds and literals are normalized away, as is the length of IN lists,
+// so queries differing only in their values share a fingerprint.
+func Fingerprint(query string) string {
+	query = fpLiteralRX.ReplaceAllString(query, "?")
+	query = fpListRX.ReplaceAllString(query, "?")
+	query = fpSpaceRX.ReplaceAllString(strings.TrimSpace(query), " ")
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.ToLower(query)))
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// explainPrefix returns the EXPLAIN prefix to use for the driver,
+// or "" if there is none
+func (d *sqDriver) explainPrefix() string {
+	if d.explain != "" {
+		return d.explain
+	}
+
+	if prefix, ok := explainPrefixes[d.toDriver]; ok {
+		return prefix
+	}
+
+	return "EXPLAIN"
+}
+
+// checkSlow logs the query if it exceeds the slow query threshold.
+// Only reads are explained, so a write is never run again (as with
+// EXPLAIN ANALYZE). Any EXPLAIN is run where the query was.
+func (c *sqConn) checkSlow(ctx context.Context, info QueryInfo, on queryer) {
+	if c.drv.slow <= 0 || info.Duration < c.drv.slow {
+		return
+	}
+
+	msg := fmt.Sprintf("SLOW QUERY: %s [%s] %s", info.Duration, Fingerprint(info.SQL), info.SQL)
+
+	if c.drv.explainSlow && info.Err == nil && isRead(info.SQL) && c.drv.explainPrefix() != "" {
+		if plan, err := c.explain(ctx, info, on); err != nil {
+			msg += "\nPLAN ERROR: " + err.Error()
+		} else {
+			msg += "\nPLAN:\n" + plan
+		}
+	}
+
+	log.Println(msg)
+}
+
+// explain runs EXPLAIN for the query, returning the plan with one line per row
+func (c *sqConn) explain(ctx context.Context, info QueryInfo, on queryer) (string, error) {
+	rows, err := on.QueryContext(ctx, c.drv.explainPrefix()+" "+info.SQL, info.Binds...)
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	vals := make([]sql.NullString, len(cols))
+	ptrs := make([]interface{}, len(cols))
+
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+
+	var lines []string
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return "", err
+		}
+
+		parts := make([]string, len(vals))
+		for i, v := range vals {
+			parts[i] = v.String
+		}
+
+		lines = append(lines, strings.Join(parts, " | "))
+	}
+
+	return strings.Join(lines, "\n"), rows.Err()
+}
@@ -0,0 +1,191 @@
+package driver_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestFingerprint() {
+	fp := driver.Fingerprint("select * from users where id IN ( ?, ?, ? ) and name = 'bob'")
+
+	s.Len(fp, 16)
+	s.Equal(fp, driver.Fingerprint("SELECT *  FROM users\nWHERE id IN ( $1 ) AND name = 'alice'"))
+	s.Equal(fp, driver.Fingerprint("select * from users where id IN ( 10, 20 ) and name = ?"))
+	s.NotEqual(fp, driver.Fingerprint("select * from orgs where id IN ( ? ) and name = ?"))
+}
+
+func (s *DriverSuite) TestSlowQuery() {
+	dsn := "slow-tests"
+
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().Nil(err)
+
+	defer mockDB.Close()
+
+	var buf bytes.Buffer
+
+	w := log.Writer()
+	defer log.SetOutput(w)
+	log.SetOutput(&buf)
+
+	open := func(o ...driver.Option) *sql.DB {
+		o = append(o, driver.Placeholder(squint.BindQuestion()), driver.SlowQuery(10*time.Millisecond))
+		c, err := driver.NewConnector(mockConnector{mockDB.Driver(), dsn}, o...)
+		s.Require().Nil(err)
+
+		return sql.OpenDB(c)
+	}
+
+	s.Run("fast", func() {
+		buf.Reset()
+
+		db := open()
+		defer db.Close()
+
+		mock.ExpectExec("delete from junk where id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := db.ExecContext(s.ctx, "delete from junk where id =", 1)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+		s.Empty(buf.String())
+	})
+
+	s.Run("slow", func() {
+		buf.Reset()
+
+		db := open()
+		defer db.Close()
+
+		query := "delete from junk where id = ?"
+		mock.ExpectExec(query).WithArgs(1).WillDelayFor(20 * time.Millisecond).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := db.ExecContext(s.ctx, "delete from junk where id =", 1)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+
+		s.Contains(buf.String(), "SLOW QUERY:")
+		s.Contains(buf.String(), "["+driver.Fingerprint(query)+"] "+query)
+		s.NotContains(buf.String(), "PLAN")
+	})
+
+	s.Run("explain", func() {
+		buf.Reset()
+
+		db := open(driver.ExplainSlow(""))
+		defer db.Close()
+
+		query := "select id from junk where id = ?"
+		mock.ExpectQuery(query).WithArgs(1).WillDelayFor(20 * time.Millisecond).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1),
+		)
+		mock.ExpectQuery("EXPLAIN " + query).WithArgs(1).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "detail"}).AddRow(1, "SCAN junk"),
+		)
+
+		var id int
+		s.Nil(db.QueryRowContext(s.ctx, "select id from junk where id =", 1).Scan(&id))
+		s.Nil(mock.ExpectationsWereMet())
+
+		s.Contains(buf.String(), "SLOW QUERY:")
+		s.Contains(buf.String(), "PLAN:\n1 | SCAN junk")
+	})
+
+	s.Run("explain prefix", func() {
+		buf.Reset()
+
+		db := open(driver.ExplainSlow("EXPLAIN ANALYZE"))
+		defer db.Close()
+
+		query := "select id from junk where id = ?"
+		mock.ExpectQuery(query).WithArgs(2).WillDelayFor(20 * time.Millisecond).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(2),
+		)
+		mock.ExpectQuery("EXPLAIN ANALYZE " + query).WithArgs(2).WillReturnRows(
+			sqlmock.NewRows([]string{"plan"}).AddRow("Seq Scan on junk"),
+		)
+
+		var id int
+		s.Nil(db.QueryRowContext(s.ctx, "select id from junk where id =", 2).Scan(&id))
+		s.Nil(mock.ExpectationsWereMet())
+		s.Contains(buf.String(), "PLAN:\nSeq Scan on junk")
+	})
+
+	s.Run("write", func() {
+		buf.Reset()
+
+		db := open(driver.ExplainSlow("EXPLAIN ANALYZE"))
+		defer db.Close()
+
+		// writes are logged, but never explained
+		query := "update junk set count = ?"
+		mock.ExpectExec(query).WithArgs(2).WillDelayFor(20 * time.Millisecond).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := db.ExecContext(s.ctx, "update junk set count =", 2)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+		s.Contains(buf.String(), "SLOW QUERY:")
+		s.NotContains(buf.String(), "PLAN")
+	})
+
+	s.Run("no default", func() {
+		buf.Reset()
+
+		// pose as a driver without a usable EXPLAIN
+		sql.Register("mssql", mockDB.Driver())
+
+		c, err := driver.NewConnector("mssql",
+			driver.DSN(dsn),
+			driver.SlowQuery(10*time.Millisecond),
+			driver.ExplainSlow(""),
+		)
+		s.Require().Nil(err)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+
+		query := "select id from junk where id = @p1"
+		mock.ExpectQuery(query).WithArgs(2).WillDelayFor(20 * time.Millisecond).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(2),
+		)
+
+		var id int
+		s.Nil(db.QueryRowContext(s.ctx, "select id from junk where id =", 2).Scan(&id))
+		s.Nil(mock.ExpectationsWereMet())
+		s.Contains(buf.String(), "SLOW QUERY:")
+		s.NotContains(buf.String(), "PLAN")
+	})
+
+	s.Run("canceled", func() {
+		buf.Reset()
+
+		db := open(driver.ExplainSlow(""))
+		defer db.Close()
+
+		ctx, cancel := context.WithCancel(s.ctx)
+
+		query := "select id from junk where id = ?"
+		mock.ExpectQuery(query).WithArgs(2).WillDelayFor(20 * time.Millisecond).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(2),
+		)
+
+		mock.ExpectQuery("EXPLAIN " + query).WithArgs(2).WillDelayFor(time.Second).WillReturnRows(
+			sqlmock.NewRows([]string{"plan"}).AddRow("SCAN junk"),
+		)
+
+		rows, err := db.QueryContext(ctx, "select id from junk where id =", 2)
+		s.Require().Nil(err)
+
+		// EXPLAIN follows the caller's context
+		cancel()
+		rows.Close()
+
+		s.Nil(mock.ExpectationsWereMet())
+		s.Contains(buf.String(), "SLOW QUERY:")
+		s.Contains(buf.String(), "PLAN ERROR: "+sqlmock.ErrCancelled.Error())
+	})
+}
@@ -36,14 +36,14 @@ func (s sqStmt) Query(args []driver.Value) (driver.Rows, error) {
 	newArgs := valsToIface(args)
 	r, err := s.Stmt.Query(newArgs...)
 
-	return sqRows{r}, err
+	return &sqRows{Rows: r}, err
 }
 
 func (s sqStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	newArgs := namedToIface(args)
 	r, err := s.Stmt.QueryContext(ctx, newArgs...)
 
-	return sqRows{r}, err
+	return &sqRows{Rows: r}, err
 }
 
 // convert from []Value to []interface{}
@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// compile-time interface checks
+var (
+	_ driver.Tx = (*sqTx)(nil)
+)
+
+// sqTx is a sql.Tx wrapper that tracks when its connection
+// leaves the transaction
+type sqTx struct {
+	*sql.Tx
+	c *sqConn
+}
+
+func (t sqTx) Commit() error {
+	t.c.inTx = false
+	return t.Tx.Commit()
+}
+
+func (t sqTx) Rollback() error {
+	t.c.inTx = false
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,104 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DB is a squint-enabled handle that shares an existing *sql.DB
+type DB struct {
+	*sql.DB
+	builder *builder
+}
+
+// Wrap an existing *sql.DB to accept squint Build() syntax in its query
+// functions. Unlike the squint driver, no other connection pool is
+// involved, so pool settings, stats and Close() all apply directly to
+// the wrapped *sql.DB, which remains usable as before.
+//
+//	db := driver.Wrap(sqlDB)
+//	rows, err := db.Query("select * from users where id in", ids)
+//
+// Only the Builder and Placeholder options apply. Queries go straight
+// to the wrapped *sql.DB, so hooks, slow query logging and replicas are
+// not supported. The bind placeholder style is not inferred, so give
+// Placeholder or a Builder if the default of BindQuestion does not suit.
+func Wrap(db *sql.DB, o ...Option) *DB {
+	drv := newDriver(o...)
+	drv.builder.style = drv.style
+
+	return &DB{DB: db, builder: drv.builder}
+}
+
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query, binds := db.builder.BuildArgs(ctx, query, args)
+	return db.DB.ExecContext(ctx, query, binds...)
+}
+
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query, binds := db.builder.BuildArgs(ctx, query, args)
+	return db.DB.QueryContext(ctx, query, binds...)
+}
+
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	query, binds := db.builder.BuildArgs(ctx, query, args)
+	return db.DB.QueryRowContext(ctx, query, binds...)
+}
+
+func (db *DB) Begin() (*Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
+
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tx{Tx: tx, builder: db.builder}, nil
+}
+
+// Tx is a squint-enabled transaction, from a DB
+type Tx struct {
+	*sql.Tx
+	builder *builder
+}
+
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.ExecContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query, binds := tx.builder.BuildArgs(ctx, query, args)
+	return tx.Tx.ExecContext(ctx, query, binds...)
+}
+
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.QueryContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query, binds := tx.builder.BuildArgs(ctx, query, args)
+	return tx.Tx.QueryContext(ctx, query, binds...)
+}
+
+func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.QueryRowContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	query, binds := tx.builder.BuildArgs(ctx, query, args)
+	return tx.Tx.QueryRowContext(ctx, query, binds...)
+}
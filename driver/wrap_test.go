@@ -0,0 +1,85 @@
+package driver_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mwblythe/squint"
+	"github.com/mwblythe/squint/driver"
+)
+
+func (s *DriverSuite) TestWrap() {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().Nil(err)
+
+	db := driver.Wrap(sqlDB, driver.Builder(squint.NewBuilder(squint.BindDollar())))
+	defer db.Close()
+
+	s.Run("exec", func() {
+		mock.ExpectExec("delete from junk where id = $1").WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := db.Exec("delete from junk where id =", 10)
+		s.Nil(err)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("query", func() {
+		mock.ExpectQuery("select id from junk where id IN ( $1, $2 )").WithArgs(1, 2).WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2),
+		)
+
+		rows, err := db.Query("select id from junk where id IN", []int{1, 2})
+		s.Require().Nil(err)
+
+		count := 0
+		for rows.Next() {
+			count++
+		}
+
+		s.Nil(rows.Close())
+		s.Equal(2, count)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("row", func() {
+		mock.ExpectQuery("select name from users where id = $1").WithArgs(5).WillReturnRows(
+			sqlmock.NewRows([]string{"name"}).AddRow("Frank"),
+		)
+
+		var name string
+		s.Nil(db.QueryRow("select name from users where id =", 5).Scan(&name))
+		s.Equal("Frank", name)
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("tx", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("update users SET name = $1 where id = $2").WithArgs("Hank", 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select name from users where id = $1").WithArgs(5).WillReturnRows(
+			sqlmock.NewRows([]string{"name"}).AddRow("Hank"),
+		)
+		mock.ExpectQuery("select id from users where name = $1").WithArgs("Hank").WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(5),
+		)
+		mock.ExpectCommit()
+
+		tx, err := db.Begin()
+		s.Require().Nil(err)
+
+		_, err = tx.Exec("update users SET", H{"name": "Hank"}, "where id =", 5)
+		s.Nil(err)
+
+		var name string
+		s.Nil(tx.QueryRow("select name from users where id =", 5).Scan(&name))
+		s.Equal("Hank", name)
+
+		rows, err := tx.Query("select id from users where name =", &name)
+		s.Require().Nil(err)
+		s.Nil(rows.Close())
+
+		s.Nil(tx.Commit())
+		s.Nil(mock.ExpectationsWereMet())
+	})
+
+	s.Run("stats", func() {
+		s.Equal(sqlDB.Stats(), db.Stats())
+	})
+}
@@ -0,0 +1,76 @@
+package squint
+
+// Query is a list of arguments that are processed as a unit.
+// After IN, it is treated as a subquery and wrapped in parentheses:
+//
+//	b.Build("SELECT * FROM users WHERE id IN", squint.Query{
+//	  "SELECT user_id FROM members WHERE org_id =", orgID,
+//	})
+type Query []interface{}
+
+// Joined is a list of parts joined by a separator
+type Joined struct {
+	sep   string
+	parts []interface{}
+}
+
+// Join will join parts with a separator, such as "," or "AND". Each
+// part can be anything accepted by Build, including a Query or Condition
+// for several arguments. Parts that produce no SQL are dropped, and the
+// separator only goes between the remaining parts:
+//
+//	b.Build(
+//	  "SELECT * FROM users WHERE",
+//	  squint.Join("AND",
+//	    squint.If(name != "", "name =", &name),
+//	    squint.If(len(ids) > 0, squint.Query{"id IN", ids}),
+//	    "active = 1",
+//	  ),
+//	)
+//
+// Options set within a part only apply to that part.
+func Join(sep string, parts ...interface{}) Joined {
+	return Joined{sep: sep, parts: parts}
+}
+
+// addQuery adds a nested query
+func (q *query) addQuery(nested Query) {
+	isSub := q.state() == stateIn
+	if isSub {
+		q.sql.Add("(")
+	}
+
+	for n := range nested {
+		q.Add(nested[n])
+	}
+
+	if isSub {
+		q.sql.Add(")")
+	}
+}
+
+// addJoined adds the non-empty parts of a join, with separators.
+// Every part follows the SQL before the join, so each is handled in
+// the same state, such as after SET.
+func (q *query) addJoined(j Joined) {
+	count := 0
+	parent := q.text()
+
+	for _, part := range j.parts {
+		sub := q.subquery()
+		sub.parent = parent
+		sub.Add(part)
+
+		if sub.sql.val == "" {
+			continue
+		}
+
+		if count > 0 {
+			q.sql.Add(j.sep)
+		}
+
+		q.sql.Add(sub.sql.val)
+		q.binds = append(q.binds, sub.binds...)
+		count++
+	}
+}
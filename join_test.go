@@ -0,0 +1,98 @@
+package squint_test
+
+import (
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestQuery() {
+	s.check(
+		"SELECT * FROM users WHERE id IN ( SELECT user_id FROM members WHERE org_id = ? ) AND active = ?",
+		binds{5, true},
+		"SELECT * FROM users WHERE id IN", squint.Query{"SELECT user_id FROM members WHERE org_id =", 5},
+		"AND active =", true,
+	)
+
+	s.check(
+		"SELECT a, b", s.empty,
+		"SELECT", squint.Query{"a,", "b"},
+	)
+}
+
+func (s *SquintSuite) TestJoin() {
+	name := "Frank"
+
+	s.Run("and", func() {
+		where := func(name string, ids []int) squint.Joined {
+			return squint.Join("AND",
+				squint.If(name != "", "name =", &name),
+				squint.If(len(ids) > 0, squint.Query{"id IN", ids}),
+				"active = 1",
+			)
+		}
+
+		s.check(
+			"WHERE name = ? AND id IN ( ?, ? ) AND active = 1", binds{"Frank", 1, 2},
+			"WHERE", where(name, []int{1, 2}),
+		)
+
+		s.check("WHERE active = 1", s.empty, "WHERE", where("", nil))
+
+		s.check(
+			"WHERE id IN ( $1 ) AND active = 1 AND x = $2", binds{1, 2},
+			squint.BindDollar(), "WHERE", where("", []int{1}), "AND x =", 2,
+		)
+	})
+
+	s.Run("comma", func() {
+		s.check(
+			"SELECT id, name FROM users", s.empty,
+			"SELECT", squint.Join(",", "id", squint.If(false, "email"), "name"), "FROM users",
+		)
+
+		s.check(
+			"UPDATE users SET a = ?, name = ?, b = ? WHERE id = ?", binds{1, "Frank", 2, 10},
+			"UPDATE users SET",
+			squint.Join(", ",
+				H{"a": 1},
+				squint.If(true, "name =", &name),
+				squint.If(false, "email =", &name),
+				H{"b": 2},
+			),
+			"WHERE id =", 10,
+		)
+
+		// later parts are still after SET
+		s.check(
+			"UPDATE t SET a = ?, b = ?, c = ?", binds{1, 2, 3},
+			"UPDATE t SET", squint.Join(",", H{"a": 1}, H{"b": 2, "c": 3}),
+		)
+
+		s.check(
+			"UPDATE t SET b = ?, c = ?", binds{2, 3},
+			"UPDATE t SET", squint.Join(",", squint.If(false, H{"a": 1}), H{"b": 2, "c": 3}),
+		)
+	})
+
+	s.Run("joins", func() {
+		s.check(
+			"SELECT * FROM users u JOIN orgs o ON o.id = u.org_id WHERE o.id = ?", binds{3},
+			"SELECT * FROM users u",
+			squint.Join("",
+				squint.If(false, "JOIN teams t ON t.id = u.team_id"),
+				squint.If(true, "JOIN orgs o ON o.id = u.org_id"),
+			),
+			"WHERE o.id =", 3,
+		)
+	})
+
+	s.Run("empty", func() {
+		s.check("SELECT", s.empty, "SELECT", squint.Join(",", squint.If(false, "a")))
+	})
+
+	s.Run("scoped", func() {
+		s.check(
+			"SET b = ?, c = ?", binds{2, 0},
+			"SET", squint.Join(",", squint.Query{squint.OmitEmpty(), H{"a": 0, "b": 2}}), ",", H{"c": 0},
+		)
+	})
+}
@@ -0,0 +1,54 @@
+package squint
+
+import (
+	sqldriver "database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// JSONValue is a value to be bound as JSON
+type JSONValue struct {
+	v interface{}
+}
+
+// JSON wraps a value so that it is marshaled with encoding/json when
+// bound. This is handy for JSON columns holding maps, slices or structs:
+//
+//	b.Build("UPDATE users SET meta =", squint.JSON(meta), "WHERE id =", id)
+//
+// A nil value is bound as NULL, and is treated as empty within a
+// struct or map. The same applies to struct fields with the json tag flag:
+//
+//	type User struct {
+//	  ID   int               `db:"id"`
+//	  Meta map[string]string `db:"meta,json"`
+//	}
+func JSON(v interface{}) JSONValue {
+	return JSONValue{v}
+}
+
+// Value implements the driver.Valuer interface
+func (j JSONValue) Value() (sqldriver.Value, error) {
+	if isNil(j.v) {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(j.v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// isNil reports whether a value is nil, or a nil pointer, map or slice
+func isNil(in interface{}) bool {
+	switch v := reflect.ValueOf(in); v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
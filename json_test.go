@@ -0,0 +1,71 @@
+package squint_test
+
+import (
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestJSON() {
+	s.Run("value", func() {
+		v, err := squint.JSON(H{"a": 1}).Value()
+		s.Nil(err)
+		s.Equal(`{"a":1}`, v)
+
+		v, err = squint.JSON([]int{1, 2}).Value()
+		s.Nil(err)
+		s.Equal(`[1,2]`, v)
+
+		var m map[string]int
+
+		v, err = squint.JSON(m).Value()
+		s.Nil(err)
+		s.Nil(v)
+
+		_, err = squint.JSON(func() {}).Value()
+		s.NotNil(err)
+	})
+
+	s.Run("bind", func() {
+		meta := squint.JSON(H{"a": 1})
+		s.check("SET meta = ?", binds{meta}, "SET meta =", meta)
+	})
+
+	type user struct {
+		ID   int               `db:"id"`
+		Meta map[string]string `db:"meta,json"`
+		Tags []string          `db:"tags,json,omitempty"`
+	}
+
+	s.Run("tag", func() {
+		_, vals := s.q.Build("SET", user{1, map[string]string{"a": "b"}, []string{"x"}})
+		s.Len(vals, 3)
+
+		v, err := vals[1].(squint.JSONValue).Value()
+		s.Nil(err)
+		s.Equal(`{"a":"b"}`, v)
+
+		v, err = vals[2].(squint.JSONValue).Value()
+		s.Nil(err)
+		s.Equal(`["x"]`, v)
+	})
+
+	s.Run("empty", func() {
+		s.check("SET id = ?, meta = ?", binds{1, nil}, squint.NullEmpty(), "SET", user{ID: 1})
+		s.check("SET id = ?", binds{1}, squint.OmitEmpty(), "SET", user{ID: 1})
+	})
+
+	s.Run("error", func() {
+		type row struct {
+			ID   int         `db:"id"`
+			Data interface{} `db:"data,json"`
+		}
+
+		for _, opt := range []squint.Option{squint.NullEmpty(), squint.OmitEmpty()} {
+			sql, vals := s.q.Build(opt, "SET", row{1, make(chan int)})
+			s.Equal("SET id = ?, data = ?", sql)
+			s.Len(vals, 2)
+
+			_, err := vals[1].(squint.JSONValue).Value()
+			s.NotNil(err)
+		}
+	})
+}
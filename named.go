@@ -0,0 +1,75 @@
+package squint
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// addNamed adds a named argument, such as from sql.Named(). Where the
+// dialect supports it, this is a native named placeholder (@id or :id),
+// and the argument itself is bound once per name for the driver to match.
+// Otherwise, the value is bound as usual. See also nameBinds.
+func (q *query) addNamed(n sql.NamedArg) {
+	prefix := q.opt.dialect.namedPrefix()
+	if prefix == "" || n.Name == "" {
+		q.addValue(n.Value)
+		return
+	}
+
+	if q.sql.lastWasBind {
+		q.sql.Add(", ")
+	}
+
+	q.sql.Add(prefix + n.Name)
+	q.sql.lastWasBind = true
+
+	if !q.hasNamed(n.Name) {
+		q.binds = append(q.binds, n)
+	}
+}
+
+// nameBinds names the positional binds after their placeholders, e.g.
+// :b2 as sql.Named("b2", v), if there are any named arguments. This
+// keeps a statement from mixing named and positional binds, which some
+// drivers (notably for oracle) don't accept.
+func (q *query) nameBinds() {
+	prefix := q.opt.dialect.namedPrefix()
+	if prefix == "" || !q.anyNamed() {
+		return
+	}
+
+	for i, b := range q.binds {
+		if _, ok := b.(sql.NamedArg); ok {
+			continue
+		}
+
+		if name := q.opt.bindFn(i + 1); strings.HasPrefix(name, prefix) {
+			q.binds[i] = sql.Named(name[len(prefix):], b)
+		}
+	}
+}
+
+// anyNamed reports whether any named arguments are bound
+func (q *query) anyNamed() bool {
+	for _, b := range q.binds {
+		if _, ok := b.(sql.NamedArg); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasNamed reports whether a named argument is already bound, either
+// by this query or by one it will become part of
+func (q *query) hasNamed(name string) bool {
+	for ; q != nil; q = q.outer {
+		for _, b := range q.binds {
+			if n, ok := b.(sql.NamedArg); ok && n.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
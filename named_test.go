@@ -0,0 +1,63 @@
+package squint_test
+
+import (
+	"database/sql"
+
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestNamed() {
+	id := sql.Named("id", 5)
+
+	s.Run("fallback", func() {
+		s.check("WHERE id = ?", binds{5}, "WHERE id =", id)
+		s.check("WHERE id = $1 OR parent = $2", binds{5, 5}, squint.BindDollar(), "WHERE id =", id, "OR parent =", id)
+	})
+
+	s.Run("sqlserver", func() {
+		s.check(
+			"WHERE id = @id OR parent = @id AND org = @p2", binds{id, sql.Named("p2", 10)},
+			squint.BindAt(), "WHERE id =", id, "OR parent =", id, "AND org =", 10,
+		)
+	})
+
+	s.Run("oracle", func() {
+		s.check(
+			"WHERE id IN ( :id, :b2 )", binds{id, sql.Named("b2", 7)},
+			squint.BindColon(), "WHERE id IN (", id, 7, ")",
+		)
+
+		// positional binds before the named one are named too
+		s.check(
+			"WHERE org = :b1 AND id = :id", binds{sql.Named("b1", 3), id},
+			squint.BindColon(), "WHERE org =", 3, "AND id =", id,
+		)
+
+		// no named arguments, no change
+		s.check("WHERE org = :b1", binds{3}, squint.BindColon(), "WHERE org =", 3)
+	})
+
+	s.Run("join", func() {
+		s.check(
+			"WHERE a = @id AND b = @id", binds{id},
+			squint.BindAt(), "WHERE", squint.Join("AND", squint.Query{"a =", id}, squint.Query{"b =", id}),
+		)
+
+		// later positional binds keep their numbering
+		s.check(
+			"WHERE a = :id AND b = :id AND c = :b2", binds{id, sql.Named("b2", 7)},
+			squint.BindColon(), "WHERE", squint.Join("AND", squint.Query{"a =", id}, squint.Query{"b =", id, "AND c =", 7}),
+		)
+	})
+
+	s.Run("out", func() {
+		var total int
+
+		out := sql.Out{Dest: &total}
+		s.check("CALL totals( ? )", binds{out}, "CALL totals(", out, ")")
+
+		named := sql.Named("total", out)
+		s.check("EXEC totals @total", binds{named}, squint.BindAt(), "EXEC totals", named)
+		s.check("CALL totals( ? )", binds{out}, "CALL totals(", named, ")")
+	})
+}
@@ -1,5 +1,7 @@
 package squint
 
+import "reflect"
+
 type emptyMode int
 
 const (
@@ -15,14 +17,58 @@ type EmptyFn func(in interface{}) (out interface{}, keep bool)
 // BindFn is a bind placholder handler
 type BindFn func(pos int) string
 
+// dialect covers SQL syntax that varies between databases
+type dialect int
+
+const (
+	dStandard  dialect = iota // mysql, postgres, sqlite
+	dSQLServer                // sqlserver
+	dOracle                   // oracle
+)
+
+// fetch reports whether OFFSET/FETCH is used rather than LIMIT/OFFSET
+func (d dialect) fetch() bool {
+	return d == dSQLServer || d == dOracle
+}
+
+// rowIn reports whether row values can be used with IN
+func (d dialect) rowIn() bool {
+	return d != dSQLServer
+}
+
+// recursive reports whether a recursive CTE needs the RECURSIVE keyword
+func (d dialect) recursive() bool {
+	return d == dStandard
+}
+
+// rowCompare reports whether row values can be compared with < and >
+func (d dialect) rowCompare() bool {
+	return d == dStandard
+}
+
+// namedPrefix is the prefix of a named placeholder, if supported
+func (d dialect) namedPrefix() string {
+	switch d {
+	case dSQLServer:
+		return "@"
+	case dOracle:
+		return ":"
+	default:
+		return ""
+	}
+}
+
 // Options for the squint Builder
 type Options struct {
-	tag      string    // field tag to use
-	empty    emptyMode // how to treat empty field values
-	logQuery bool      // log queries?
-	logBinds bool      // log binds?
-	emptyFn  EmptyFn   // custom empty field handler
-	bindFn   BindFn    // bind placeholder handler
+	tag      string                   // field tag to use
+	empty    emptyMode                // how to treat empty field values
+	logQuery bool                     // log queries?
+	logBinds bool                     // log binds?
+	emptyFn  EmptyFn                  // custom empty field handler
+	bindFn   BindFn                   // bind placeholder handler
+	bindSet  bool                     // bind style given, not defaulted
+	dialect  dialect                  // SQL dialect, as implied by bind style
+	types    map[reflect.Type]Handler // registered type handlers
 
 	// deprecated
 	emptyValues bool
@@ -32,6 +78,12 @@ type Options struct {
 // Option is a functional option
 type Option func(*Options)
 
+// HasBind reports whether a bind placeholder style was given, rather
+// than left as the default
+func (o *Options) HasBind() bool {
+	return o.bindSet
+}
+
 // SetOption applies the given options
 func (o *Options) SetOption(options ...Option) {
 	for _, opt := range options {
@@ -113,6 +165,8 @@ func WithDefaultEmpty() Option {
 func BindQuestion() Option {
 	return func(o *Options) {
 		o.bindFn = bindQuestion
+		o.bindSet = true
+		o.dialect = dStandard
 	}
 }
 
@@ -120,6 +174,8 @@ func BindQuestion() Option {
 func BindAt() Option {
 	return func(o *Options) {
 		o.bindFn = bindAt
+		o.bindSet = true
+		o.dialect = dSQLServer
 	}
 }
 
@@ -127,6 +183,8 @@ func BindAt() Option {
 func BindDollar() Option {
 	return func(o *Options) {
 		o.bindFn = bindDollar
+		o.bindSet = true
+		o.dialect = dStandard
 	}
 }
 
@@ -134,6 +192,8 @@ func BindDollar() Option {
 func BindColon() Option {
 	return func(o *Options) {
 		o.bindFn = bindColon
+		o.bindSet = true
+		o.dialect = dOracle
 	}
 }
 
@@ -145,5 +205,6 @@ func BindColon() Option {
 func WithBindFn(fn BindFn) Option {
 	return func(o *Options) {
 		o.bindFn = fn
+		o.bindSet = true
 	}
 }
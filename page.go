@@ -0,0 +1,178 @@
+package squint
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Paging is a LIMIT/OFFSET clause
+type Paging struct {
+	limit  int
+	offset int
+}
+
+// Page adds a clause to limit and offset the query results.
+// The syntax depends on the bind style in effect:
+//
+//	BindQuestion, BindDollar : LIMIT ? OFFSET ?
+//	BindAt, BindColon        : OFFSET ? ROWS FETCH NEXT ? ROWS ONLY
+//
+// A zero limit is left out, as is a zero offset where the syntax allows.
+// With LIMIT, an offset alone needs a limit too, so the largest is used.
+func Page(limit, offset int) Paging {
+	return Paging{limit: limit, offset: offset}
+}
+
+// Keyset is a keyset pagination condition
+type Keyset struct {
+	cursor interface{}
+	cols   []string
+}
+
+// After adds a condition to select rows following a cursor, for keyset
+// pagination. The cursor is a struct or map holding the ordering values
+// of the last row seen. The order columns should match the query's
+// ORDER BY, and may have a DESC suffix:
+//
+//	sql, binds := b.Build(
+//	  "SELECT * FROM users WHERE",
+//	  squint.After(last, "created DESC", "id DESC"),
+//	  "ORDER BY created DESC, id DESC",
+//	  squint.Page(20, 0),
+//	)
+//
+// If no order columns are given, all of the cursor's columns are used
+// in ascending order. Order columns missing from the cursor are ignored.
+func After(cursor interface{}, orderCols ...string) Keyset {
+	return Keyset{cursor: cursor, cols: orderCols}
+}
+
+// addPage adds a paging clause to the query
+func (q *query) addPage(p Paging) {
+	if q.opt.dialect.fetch() {
+		if p.limit > 0 || p.offset > 0 {
+			q.sql.Add("OFFSET")
+			q.addBind(p.offset)
+			q.sql.Add("ROWS")
+		}
+
+		if p.limit > 0 {
+			q.sql.Add("FETCH NEXT")
+			q.addBind(p.limit)
+			q.sql.Add("ROWS ONLY")
+		}
+
+		return
+	}
+
+	if p.limit > 0 {
+		q.sql.Add("LIMIT")
+		q.addBind(p.limit)
+	} else if p.offset > 0 {
+		// an OFFSET needs a LIMIT, so use the largest
+		q.sql.Add("LIMIT")
+		q.addBind(int64(math.MaxInt64))
+	}
+
+	if p.offset > 0 {
+		q.sql.Add("OFFSET")
+		q.addBind(p.offset)
+	}
+}
+
+// addKeyset adds a keyset pagination condition to the query
+func (q *query) addKeyset(k Keyset) {
+	v := reflect.ValueOf(k.cursor)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	// cursor values are used as-is, regardless of empty mode
+	q.keepAll = true
+	cols, binds := q.sift(&v)
+	q.keepAll = false
+
+	valmap := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		valmap[col] = binds[i]
+	}
+
+	if len(k.cols) == 0 {
+		k.cols = cols
+	}
+
+	// resolve order columns and their direction
+	var names, ops []string
+
+	var vals []interface{}
+
+	for _, col := range k.cols {
+		parts := strings.Fields(col)
+		if len(parts) == 0 {
+			continue
+		}
+
+		val, ok := valmap[parts[0]]
+		if !ok {
+			continue
+		}
+
+		op := ">"
+		if len(parts) > 1 && strings.EqualFold(parts[1], "DESC") {
+			op = "<"
+		}
+
+		names = append(names, parts[0])
+		ops = append(ops, op)
+		vals = append(vals, val)
+	}
+
+	switch {
+	case len(names) == 0:
+		return
+	case len(names) == 1:
+		q.sql.Add(names[0] + " " + ops[0])
+		q.addValue(vals[0])
+	case q.opt.dialect.rowCompare() && sameOps(ops):
+		q.sql.Add("( " + strings.Join(names, ", ") + " ) " + ops[0])
+		q.sql.Add("(")
+		q.addValues(vals...)
+		q.sql.Add(")")
+	default:
+		// (a > ? OR (a = ? AND b > ?) OR ...)
+		q.sql.Add("(")
+
+		for i := range names {
+			if i > 0 {
+				q.sql.Add("OR (")
+			}
+
+			for j := 0; j < i; j++ {
+				q.sql.Add(names[j] + " =")
+				q.addValue(vals[j])
+				q.sql.Add("AND")
+			}
+
+			q.sql.Add(names[i] + " " + ops[i])
+			q.addValue(vals[i])
+
+			if i > 0 {
+				q.sql.Add(")")
+			}
+		}
+
+		q.sql.Add(")")
+	}
+}
+
+// sameOps reports whether all comparison operators are the same
+func sameOps(ops []string) bool {
+	for _, op := range ops {
+		if op != ops[0] {
+			return false
+		}
+	}
+
+	return true
+}
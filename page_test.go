@@ -0,0 +1,76 @@
+package squint_test
+
+import (
+	"math"
+
+	"github.com/mwblythe/squint"
+)
+
+func (s *SquintSuite) TestPage() {
+	s.check("LIMIT ? OFFSET ?", binds{10, 20}, squint.Page(10, 20))
+	s.check("LIMIT ?", binds{10}, squint.Page(10, 0))
+	s.check("LIMIT ? OFFSET ?", binds{int64(math.MaxInt64), 20}, squint.Page(0, 20))
+	s.check("", s.empty, squint.Page(0, 0))
+
+	s.check(
+		"SELECT * FROM users LIMIT $1 OFFSET $2", binds{10, 20},
+		squint.BindDollar(), "SELECT * FROM users", squint.Page(10, 20),
+	)
+
+	s.check(
+		"SELECT * FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY", binds{20, 10},
+		squint.BindAt(), "SELECT * FROM users ORDER BY id", squint.Page(10, 20),
+	)
+
+	s.check(
+		"OFFSET :b1 ROWS FETCH NEXT :b2 ROWS ONLY", binds{0, 10},
+		squint.BindColon(), squint.Page(10, 0),
+	)
+}
+
+func (s *SquintSuite) TestAfter() {
+	type cursor struct {
+		Created string `db:"created"`
+		ID      int    `db:"id"`
+	}
+
+	last := cursor{"2020-01-01", 10}
+
+	s.Run("single", func() {
+		s.check("WHERE id > ?", binds{10}, "WHERE", squint.After(last, "id"))
+		s.check("WHERE id < ?", binds{10}, "WHERE", squint.After(&last, "id desc"))
+	})
+
+	s.Run("row", func() {
+		s.check(
+			"WHERE ( created, id ) > ( ?, ? )", binds{"2020-01-01", 10},
+			"WHERE", squint.After(last),
+		)
+
+		s.check(
+			"WHERE ( created, id ) < ( $1, $2 )", binds{"2020-01-01", 10},
+			squint.BindDollar(), "WHERE", squint.After(last, "created DESC", "id DESC"),
+		)
+	})
+
+	s.Run("expanded", func() {
+		s.check(
+			"WHERE ( created > @p1 OR ( created = @p2 AND id > @p3 ) )",
+			binds{"2020-01-01", "2020-01-01", 10},
+			squint.BindAt(), "WHERE", squint.After(last, "created", "id"),
+		)
+
+		s.check(
+			"WHERE ( created < ? OR ( created = ? AND id > ? ) )",
+			binds{"2020-01-01", "2020-01-01", 10},
+			"WHERE", squint.After(last, "created DESC", "id"),
+		)
+	})
+
+	s.Run("map", func() {
+		s.check(
+			"WHERE ( a, b ) > ( ?, ? )", binds{0, ""},
+			squint.OmitEmpty(), "WHERE", squint.After(H{"a": 0, "b": ""}, "a", "b", "c"),
+		)
+	})
+}
@@ -1,6 +1,7 @@
 package squint
 
 import (
+	"database/sql"
 	sqldriver "database/sql/driver"
 	"fmt"
 	"reflect"
@@ -51,25 +52,118 @@ const (
 
 var insertRX = regexp.MustCompile(`(?i)\b(INSERT|REPLACE)\s+(?:\w+\s+)*INTO\s+\S+\s*$`)
 var setRX = regexp.MustCompile(`(?i)\bSET\s*$`)
+var insertSetRX = regexp.MustCompile(`(?i)\b(INSERT|REPLACE)\s+(?:\w+\s+)*INTO\s+\S+\s+SET\s*$`)
 var inRX = regexp.MustCompile(`(?i)\bIN\s*$`)
 
+var valuerType = reflect.TypeOf((*sqldriver.Valuer)(nil)).Elem()
+var appenderType = reflect.TypeOf((*Appender)(nil)).Elem()
+
 // query represents a single SQL query that is being built
 type query struct {
 	opt   Options
 	sql   sqlBuf
 	binds []interface{}
 
-	keepAll bool // internal override of empty mode
+	keepAll bool      // internal override of empty mode
+	pk      pkFilter  // internal column filter by primary key
+	write   writeMode // internal kind of write being sifted for
+	nilFill bool      // internal use of nil for all field values
+	inherit fieldFlag // internal flags of the enclosing inline field
+
+	parent   string   // SQL of the parent query, for a subquery
+	bindBase int      // number of binds before this query
+	outer    *query   // query this one will become part of, if any
+	with     withList // current list of common table expressions
+}
+
+// writeMode is the kind of write that struct fields are sifted for
+type writeMode uint8
+
+// write modes
+const (
+	writeNone writeMode = iota
+	writeInsert
+	writeUpdate
+)
+
+// pkFilter selects struct fields by primary key status
+type pkFilter uint8
+
+// primary key filters
+const (
+	pkAll pkFilter = iota
+	pkOnly
+	pkNone
+)
+
+// fieldFlag is a flag set in a field tag
+type fieldFlag uint8
+
+// field flags
+const (
+	flagPK       fieldFlag = 1 << iota // primary key
+	flagNoInsert                       // never inserted
+	flagNoUpdate                       // never updated
+	flagInline                         // nested struct is flattened
+	flagJSON                           // value is bound as JSON
+
+	flagReadOnly = flagNoInsert | flagNoUpdate // never written
+	flagInherit  = flagPK | flagReadOnly       // passed on to inline fields
+)
+
+// fieldMap is the mapping of a struct field to a db column
+type fieldMap struct {
+	name   string    // column name
+	mode   emptyMode // empty mode override
+	flags  fieldFlag // tag flags
+	prefix string    // column prefix for inline structs
+}
+
+// writeMode returns the kind of write for a given state
+func (q *query) writeMode(state sqlState) writeMode {
+	switch {
+	case state == stateInsert:
+		return writeInsert
+	case state == stateSet && insertSetRX.MatchString(q.text()):
+		return writeInsert
+	case state == stateSet:
+		return writeUpdate
+	default:
+		return writeNone
+	}
+}
+
+// text returns the query's SQL so far, for determining state.
+// A subquery with no SQL yet uses that of its parent.
+func (q *query) text() string {
+	if q.sql.val == "" {
+		return q.parent
+	}
+
+	return q.sql.val
+}
+
+// subquery returns a new query to separately render bits that will
+// become part of this one
+func (q *query) subquery() *query {
+	return &query{
+		opt:      q.opt,
+		parent:   q.text(),
+		bindBase: q.bindBase + len(q.binds),
+		outer:    q,
+	}
 }
 
 // state returns the query's current state
 func (q *query) state() sqlState {
+	text := q.text()
+
 	switch {
-	case insertRX.MatchString(q.sql.val):
+	case insertRX.MatchString(text):
 		return stateInsert
-	case setRX.MatchString(q.sql.val):
+	case setRX.MatchString(text):
 		return stateSet
-	case inRX.MatchString(q.sql.val):
+	case inRX.MatchString(text):
 		return stateIn
 	default:
 		return stateBase
@@ -78,6 +172,16 @@ func (q *query) state() sqlState {
 
 // Add a piece to the query
 func (q *query) Add(bit interface{}) {
+	if a, ok := bit.(Appender); ok {
+		q.addAppender(a)
+		return
+	}
+
+	if h, ok := q.handler(bit); ok {
+		q.addHandled(h, bit)
+		return
+	}
+
 	if valuer, ok := bit.(sqldriver.Valuer); ok {
 		// check if the valuer is a nil pointer
 		v := reflect.ValueOf(valuer)
@@ -92,8 +196,30 @@ func (q *query) Add(bit interface{}) {
 	switch b := bit.(type) {
 	case Condition:
 		q.addCondition(b)
+	case Scope:
+		q.addScope(b)
+	case Lazy:
+		q.Add(b())
+	case Query:
+		q.addQuery(b)
+	case Joined:
+		q.addJoined(b)
+	case CommonTable:
+		q.addCTE(b)
+	case Paging:
+		q.addPage(b)
+	case Keyset:
+		q.addKeyset(b)
+	case Statement:
+		q.addStatement(b)
+	case Changeset:
+		q.addChangeset(b)
 	case Option:
 		q.opt.SetOption(b)
+	case sql.NamedArg:
+		q.addNamed(b)
+	case sql.Out:
+		q.addBind(b)
 	default:
 		v := reflect.ValueOf(bit)
 
@@ -118,19 +244,40 @@ func (q *query) addBind(values ...interface{}) {
 			q.sql.Add(", ")
 		}
 
-		q.sql.Add(q.opt.bindFn(len(q.binds) + 1))
+		q.sql.Add(q.opt.bindFn(q.bindBase + len(q.binds) + 1))
 		q.binds = append(q.binds, v)
 		q.sql.lastWasBind = true
 	}
 }
 
-// addCondition adds bits to the query if condition is true
+// addCondition adds bits to the query if condition is true,
+// or the else bits if not
 func (q *query) addCondition(c Condition) {
-	if c.isTrue {
-		for n := range c.bits {
-			q.Add(c.bits[n])
-		}
+	isTrue := c.isTrue
+	if c.test != nil {
+		isTrue = c.test()
+	}
+
+	bits := c.elseBits
+	if isTrue {
+		bits = c.bits
 	}
+
+	for n := range bits {
+		q.Add(bits[n])
+	}
+}
+
+// addScope adds bits to the query with scoped options
+func (q *query) addScope(s Scope) {
+	saved := q.opt
+	q.opt.SetOption(s.opts...)
+
+	for n := range s.bits {
+		q.Add(s.bits[n])
+	}
+
+	q.opt = saved
 }
 
 // addString adds a string to the query.
@@ -165,13 +312,18 @@ func (q *query) addSlice(v reflect.Value) {
 	ty := v.Type().Elem().Kind()
 
 	switch {
+	case (state == stateIn || state == stateBase) && q.isRow(v.Type().Elem()) && q.addRows(v, state):
+		// handled as row values
 	case state == stateIn:
 		q.sql.Add("(")
 
-		for i := 0; i < v.Len(); i++ {
-			q.addBind(v.Index(i).Interface())
+		vals := make([]interface{}, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Interface()
 		}
 
+		q.addValues(vals...)
+
 		if v.Len() == 0 {
 			q.sql.Add("NULL")
 		}
@@ -181,6 +333,7 @@ func (q *query) addSlice(v reflect.Value) {
 		// multi-row inserts MUST have the same number of binds per row
 		// so we keep all values
 		q.keepAll = true
+		q.write = writeInsert
 
 		for i := 0; i < v.Len(); i++ {
 			el := v.Index(i)
@@ -193,11 +346,12 @@ func (q *query) addSlice(v reflect.Value) {
 			}
 
 			q.sql.Add("(")
-			q.addBind(binds...)
+			q.addValues(binds...)
 			q.sql.Add(")")
 		}
 
 		q.keepAll = false
+		q.write = writeNone
 	default:
 		for i := 0; i < v.Len(); i++ {
 			q.Add(v.Index(i).Interface())
@@ -205,15 +359,182 @@ func (q *query) addSlice(v reflect.Value) {
 	}
 }
 
+// isRow reports whether a slice element type could be a row value,
+// i.e. a struct or map that isn't otherwise handled as a single value
+func (q *query) isRow(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if _, ok := q.opt.types[t]; ok {
+		return false
+	}
+
+	for _, it := range []reflect.Type{valuerType, appenderType} {
+		if t.Implements(it) || reflect.PtrTo(t).Implements(it) {
+			return false
+		}
+	}
+
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Map
+}
+
+// addRows adds a slice of structs or maps as row values, for composite
+// key lookups. After IN, it is a list of rows. Otherwise, the column list
+// is included, or an OR of each row if row values aren't supported or the
+// rows have differing columns. After IN, rows missing any column are left
+// out, as they could never match. It returns false (and adds nothing) if
+// the rows have no columns.
+func (q *query) addRows(v reflect.Value, state sqlState) bool {
+	var cols []string
+
+	seen := make(map[string]bool)
+	rowCols := make([][]string, v.Len())
+	rows := make([][]interface{}, v.Len())
+
+	// every column is kept, so the rows line up
+	q.keepAll = true
+
+	for i := range rows {
+		el := elemValue(v.Index(i))
+		rowCols[i], rows[i] = q.sift(&el)
+
+		// collect all columns, as maps may differ
+		for _, col := range rowCols[i] {
+			if !seen[col] {
+				seen[col] = true
+				cols = append(cols, col)
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		el := elemValue(v)
+		cols, _ = q.sift(&el)
+	}
+
+	q.keepAll = false
+
+	if elemValue(v).Kind() == reflect.Map {
+		sort.Strings(cols)
+	}
+
+	if len(cols) == 0 {
+		return false
+	}
+
+	// rows of maps may have differing columns
+	same := true
+
+	for i := range rows {
+		if len(rowCols[i]) != len(cols) {
+			same = false
+		}
+	}
+
+	if state == stateBase && (!same || !q.opt.dialect.rowIn()) {
+		// ( ( a = ? AND b = ? ) OR ( ... ) ), with the columns of each row
+		if len(rows) == 0 {
+			rowCols = [][]string{cols}
+			rows = [][]interface{}{make([]interface{}, len(cols))}
+		}
+
+		q.sql.Add("(")
+
+		n := 0
+
+		for i, row := range rows {
+			if len(row) == 0 {
+				continue // an empty row has nothing to match
+			}
+
+			if n > 0 {
+				q.sql.Add("OR")
+			}
+
+			q.sql.Add("(")
+			q.addColumns(stateBase, rowCols[i], row)
+			q.sql.Add(")")
+
+			n++
+		}
+
+		q.sql.Add(")")
+
+		return true
+	}
+
+	// row values need every column, so rows missing any can never match
+	complete := rows[:0]
+
+	for i := range rows {
+		if len(rowCols[i]) == len(cols) {
+			complete = append(complete, alignRow(cols, rowCols[i], rows[i]))
+		}
+	}
+
+	rows = complete
+
+	if len(rows) == 0 {
+		// a row of nulls will match nothing
+		rows = [][]interface{}{make([]interface{}, len(cols))}
+	}
+
+	if state == stateBase {
+		q.sql.Add("( " + strings.Join(cols, ", ") + " ) IN")
+	}
+
+	q.sql.Add("(")
+
+	for i, row := range rows {
+		if i > 0 {
+			q.sql.Add(",")
+		}
+
+		q.sql.Add("(")
+		q.addValues(row...)
+		q.sql.Add(")")
+	}
+
+	q.sql.Add(")")
+
+	return true
+}
+
+// alignRow returns binds in the order of the given columns
+func alignRow(want, cols []string, binds []interface{}) []interface{} {
+	valmap := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		valmap[col] = binds[i]
+	}
+
+	row := make([]interface{}, len(want))
+	for i, col := range want {
+		row[i] = valmap[col]
+	}
+
+	return row
+}
+
 // addComplex adds a struct or map to the query
 func (q *query) addComplex(v reflect.Value) {
+	state := q.state()
+
+	q.write = q.writeMode(state)
 	cols, binds := q.sift(&v)
+	q.write = writeNone
 
-	switch q.state() {
+	q.addColumns(state, cols, binds)
+}
+
+// addColumns adds columns and their binds to the query, in a style
+// suited to the given state
+func (q *query) addColumns(state sqlState, cols []string, binds []interface{}) {
+	switch state {
 	case stateInsert:
 		if len(cols) > 0 {
 			q.sql.Add("( " + strings.Join(cols, ", ") + " ) VALUES (")
-			q.addBind(binds...)
+			q.addValues(binds...)
 			q.sql.Add(")")
 		}
 	case stateSet:
@@ -223,7 +544,7 @@ func (q *query) addComplex(v reflect.Value) {
 			}
 
 			q.sql.Add(col + " = ")
-			q.addBind(binds[i])
+			q.addValue(binds[i])
 		}
 	default:
 		for i, col := range cols {
@@ -231,13 +552,15 @@ func (q *query) addComplex(v reflect.Value) {
 				q.sql.Add("AND")
 			}
 
-			switch bv := reflect.ValueOf(binds[i]); bv.Kind() {
-			case reflect.Slice, reflect.Array:
+			_, handled := q.handler(binds[i])
+
+			switch bv := reflect.ValueOf(binds[i]); {
+			case !handled && (bv.Kind() == reflect.Slice || bv.Kind() == reflect.Array):
 				q.sql.Add(col + " IN")
 				q.addSlice(bv)
 			default:
 				q.sql.Add(col + " = ")
-				q.addBind(binds[i])
+				q.addValue(binds[i])
 			}
 		}
 	}
@@ -290,19 +613,43 @@ func (q *query) siftStruct(src *reflect.Value) ([]string, []interface{}) {
 		field := src.Type().Field(i)
 		fieldVal := src.Field(i)
 
-		if fieldVal.Kind() == reflect.Struct && field.Anonymous {
+		if field.Anonymous && q.isNested(fieldVal) {
 			if q.tagValue(field) != "-" {
-				c, b := q.siftStruct(&fieldVal)
+				c, b := q.siftNested(fieldVal)
 				cols = append(cols, c...)
 				binds = append(binds, b...)
 			}
 		} else {
-			name, mode := q.mapField(field)
-			if name == "" {
+			fm := q.mapField(field)
+			if fm.name == "" {
 				continue
 			}
-			if v, ok := q.checkValue(fieldVal.Interface(), mode); ok {
-				cols = append(cols, name)
+			fm.flags |= q.inherit
+			if fm.flags&flagInline != 0 && q.isNested(fieldVal) {
+				inherit := q.inherit
+				q.inherit = fm.flags & flagInherit
+				c, b := q.siftNested(fieldVal)
+				q.inherit = inherit
+				for _, col := range c {
+					cols = append(cols, fm.prefix+col)
+				}
+				binds = append(binds, b...)
+				continue
+			}
+			if !q.wantField(fm) {
+				continue
+			}
+
+			val := fieldVal.Interface()
+			if q.nilFill {
+				val = nil
+			}
+			if fm.flags&flagJSON != 0 {
+				val = JSON(val)
+			}
+
+			if v, ok := q.checkValue(val, fm.mode); ok {
+				cols = append(cols, fm.name)
 				binds = append(binds, v)
 			}
 		}
@@ -311,6 +658,37 @@ func (q *query) siftStruct(src *reflect.Value) ([]string, []interface{}) {
 	return cols, binds
 }
 
+// siftNested sifts a nested struct or struct pointer into cols + binds.
+// A nil pointer yields the struct's columns, with nil values.
+func (q *query) siftNested(v reflect.Value) ([]string, []interface{}) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			z := reflect.Zero(v.Type().Elem())
+			nilFill := q.nilFill
+			q.nilFill = true
+			cols, binds := q.siftStruct(&z)
+			q.nilFill = nilFill
+
+			return cols, binds
+		}
+
+		v = v.Elem()
+	}
+
+	return q.siftStruct(&v)
+}
+
+// isNested reports whether a value is a struct or struct pointer
+// that can be flattened, i.e. without a registered handler
+func (q *query) isNested(v reflect.Value) bool {
+	if _, ok := q.opt.types[v.Type()]; ok {
+		return false
+	}
+
+	return v.Kind() == reflect.Struct ||
+		v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct
+}
+
 // keepValue determines whether a given value should be kept when sifting
 // a struct or map into columns and binds. This is controlled by the empty mode.
 func (q *query) checkValue(in interface{}, mode emptyMode) (interface{}, bool) {
@@ -323,6 +701,9 @@ func (q *query) checkValue(in interface{}, mode emptyMode) (interface{}, bool) {
 			v = rv
 		} else if val, err := valuer.Value(); err == nil {
 			v = reflect.ValueOf(val)
+		} else {
+			// keep it, so the error surfaces when the query is run
+			return in, true
 		}
 	} else {
 		v = reflect.ValueOf(in)
@@ -366,8 +747,27 @@ func (q *query) tagValue(field reflect.StructField) string {
 	return ""
 }
 
-// mapField maps a struct field to a db column name
-func (q *query) mapField(field reflect.StructField) (name string, mode emptyMode) {
+// wantField determines whether a mapped field is used in the current context
+func (q *query) wantField(fm fieldMap) bool {
+	switch {
+	case q.write == writeInsert && fm.flags&flagNoInsert != 0:
+		return false
+	case q.write == writeUpdate && fm.flags&flagNoUpdate != 0:
+		return false
+	}
+
+	switch q.pk {
+	case pkOnly:
+		return fm.flags&flagPK != 0
+	case pkNone:
+		return fm.flags&flagPK == 0
+	default:
+		return true
+	}
+}
+
+// mapField maps a struct field to a db column
+func (q *query) mapField(field reflect.StructField) (fm fieldMap) {
 	// check for unexported fields
 	if field.PkgPath != "" {
 		return
@@ -382,19 +782,35 @@ func (q *query) mapField(field reflect.StructField) (name string, mode emptyMode
 	for _, t := range strings.Split(tag, ",") {
 		switch t {
 		case "keepempty":
-			mode = eKeep
+			fm.mode = eKeep
 		case "omitempty":
-			mode = eOmit
+			fm.mode = eOmit
 		case "nullempty":
-			mode = eNull
+			fm.mode = eNull
+		case "pk":
+			fm.flags |= flagPK
+		case "noinsert":
+			fm.flags |= flagNoInsert
+		case "noupdate":
+			fm.flags |= flagNoUpdate
+		case "readonly":
+			fm.flags |= flagReadOnly
+		case "inline":
+			fm.flags |= flagInline
+		case "json":
+			fm.flags |= flagJSON
 		default:
-			name = t
+			if strings.HasPrefix(t, "prefix=") {
+				fm.prefix = strings.TrimPrefix(t, "prefix=")
+			} else {
+				fm.name = t
+			}
 		}
 	}
 
 	// default to field name itself
-	if name == "" {
-		name = field.Name
+	if fm.name == "" {
+		fm.name = field.Name
 	}
 
 	return
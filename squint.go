@@ -10,8 +10,32 @@ type Bind string
 
 // Condition will conditionally process a list of arguments
 type Condition struct {
-	isTrue bool
-	bits   []interface{}
+	isTrue   bool
+	test     func() bool
+	bits     []interface{}
+	elseBits []interface{}
+}
+
+// SwitchCase is a case within a Switch
+type SwitchCase struct {
+	match     interface{}
+	isDefault bool
+	bits      []interface{}
+}
+
+// Lazy is a function that is only called if and when the query reaches
+// it, such as within a Condition that is true. The value it returns is
+// processed in its place:
+//
+//	squint.If(withOrg, "JOIN orgs o ON o.id =", squint.Lazy(func() interface{} {
+//	  return lookupOrg(ctx)
+//	}))
+type Lazy func() interface{}
+
+// Scope will process a list of arguments with its own options
+type Scope struct {
+	opts []Option
+	bits []interface{}
 }
 
 // Builder is the core of public squint interactions.
@@ -25,6 +49,7 @@ func NewBuilder(options ...Option) *Builder {
 	var b Builder
 
 	b.SetOption(Tag("db"), BindQuestion(), KeepEmpty())
+	b.bindSet = false
 	b.SetOption(options...)
 
 	return &b
@@ -44,6 +69,8 @@ func (b *Builder) Build(bits ...interface{}) (string, []interface{}) {
 		q.Add(bit)
 	}
 
+	q.nameBinds()
+
 	if q.opt.logQuery {
 		log.Println("SQL:", q.sql.val)
 	}
@@ -79,6 +106,89 @@ func If(condition bool, bits ...interface{}) Condition {
 	}
 }
 
+// IfElse will include one argument if the condition is true,
+// and the other if it is false:
+//
+//	squint.IfElse(asc, "ORDER BY id", "ORDER BY id DESC")
+func IfElse(condition bool, thenBit, elseBit interface{}) Condition {
+	return Condition{
+		isTrue:   condition,
+		bits:     []interface{}{thenBit},
+		elseBits: []interface{}{elseBit},
+	}
+}
+
+// When is like If, but the condition is a function that is only
+// evaluated when the query reaches it
+func When(condition func() bool, bits ...interface{}) Condition {
+	return Condition{
+		test: condition,
+		bits: bits,
+	}
+}
+
+// Switch will include the arguments of the first case that matches
+// the value, or else those of the default case (if any):
+//
+//	squint.Switch(sort,
+//	  squint.Case("name", "ORDER BY last, first"),
+//	  squint.Case("age", "ORDER BY birthday DESC"),
+//	  squint.Default("ORDER BY id"),
+//	)
+//
+// Values are compared with reflect.DeepEqual.
+func Switch(value interface{}, cases ...SwitchCase) Condition {
+	var def *SwitchCase
+
+	for n := range cases {
+		switch c := &cases[n]; {
+		case c.isDefault:
+			if def == nil {
+				def = c
+			}
+		case reflect.DeepEqual(value, c.match):
+			return If(true, c.bits...)
+		}
+	}
+
+	if def != nil {
+		return If(true, def.bits...)
+	}
+
+	return If(false)
+}
+
+// Case is a case for Switch, including the arguments if the value matches
+func Case(match interface{}, bits ...interface{}) SwitchCase {
+	return SwitchCase{match: match, bits: bits}
+}
+
+// Default is the default case for Switch, when no other case matches
+func Default(bits ...interface{}) SwitchCase {
+	return SwitchCase{isDefault: true, bits: bits}
+}
+
+// With applies options only to the given list of arguments. The options
+// in effect before are restored afterwards, including any options set
+// within the list itself:
+//
+//	sql, binds := b.Build(
+//	  "UPDATE users SET",
+//	  b.With([]squint.Option{squint.OmitEmpty()}, updates),
+//	  "WHERE", filter,
+//	)
+func (b *Builder) With(opts []Option, bits ...interface{}) Scope {
+	return With(opts, bits...)
+}
+
+// With : package level version, in case Builder instance isn't handy
+func With(opts []Option, bits ...interface{}) Scope {
+	return Scope{
+		opts: opts,
+		bits: bits,
+	}
+}
+
 // HasValues evaluates whether a struct or map has values that
 // would be used according to the Builder's options.
 //
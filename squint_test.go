@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/mwblythe/squint"
 	"github.com/stretchr/testify/suite"
@@ -119,6 +120,113 @@ func (s *SquintSuite) TestStruct() {
 		)
 	})
 
+	s.Run("embed-ptr", func() {
+		type audit struct {
+			By string `db:"by"`
+			At int    `db:"at,keepempty"`
+		}
+
+		type row struct {
+			ID int `db:"id"`
+			*audit
+		}
+
+		s.check(
+			"SET id = ?, by = ?, at = ?", binds{1, "Frank", 10},
+			"SET", row{1, &audit{"Frank", 10}},
+		)
+
+		s.check(
+			"SET id = ?, by = ?, at = ?", binds{1, nil, nil},
+			"SET", row{1, nil},
+		)
+
+		s.check(
+			"SET id = ?, at = ?", binds{1, nil},
+			squint.OmitEmpty(), "SET", row{1, nil},
+		)
+
+		s.check(
+			"SET id = ?", binds{1},
+			"SET", struct {
+				ID     int `db:"id"`
+				*audit `db:"-"`
+			}{1, nil},
+		)
+
+		s.check(
+			"SET id = ?, a_by = ?, a_at = ?", binds{1, "Hank", 0},
+			"SET", struct {
+				ID    int    `db:"id"`
+				Audit *audit `db:",inline,prefix=a_"`
+			}{1, &audit{By: "Hank"}},
+		)
+	})
+
+	s.Run("inline", func() {
+		type address struct {
+			Street string `db:"street"`
+			City   string `db:"city"`
+		}
+
+		type customer struct {
+			ID   int     `db:"id"`
+			Home address `db:"home,inline,prefix=home_"`
+			Work address `db:"work,inline"`
+		}
+
+		c := customer{1, address{"Main", "Here"}, address{"Side", "There"}}
+
+		s.check(
+			"WHERE id = ? AND home_street = ? AND home_city = ? AND street = ? AND city = ?",
+			binds{1, "Main", "Here", "Side", "There"},
+			"WHERE", c,
+		)
+
+		s.check(
+			"INSERT INTO customers ( id, home_street, home_city, street, city ) VALUES ( ?, ?, ?, ?, ? )",
+			binds{1, "Main", "Here", "Side", "There"},
+			"INSERT INTO customers", c,
+		)
+
+		s.check(
+			"UPDATE customers SET home_street = ?, home_city = ?",
+			binds{"Main", "Here"},
+			"UPDATE customers SET", struct {
+				Home address `db:",inline,prefix=home_"`
+			}{c.Home},
+		)
+
+		// flags of the inline field apply to its columns
+		type account struct {
+			ID   int     `db:"id"`
+			Home address `db:"home,inline,prefix=h_,readonly"`
+			Work address `db:"work,inline,prefix=w_,noupdate"`
+		}
+
+		a := account{1, address{"Main", "Here"}, address{"Side", "There"}}
+
+		s.check(
+			"UPDATE t SET id = ?", binds{1},
+			"UPDATE t SET", a,
+		)
+
+		s.check(
+			"INSERT INTO t ( id, w_street, w_city ) VALUES ( ?, ?, ? )", binds{1, "Side", "There"},
+			"INSERT INTO t", a,
+		)
+
+		type keyed struct {
+			Key  address `db:"key,inline,pk"`
+			Note string  `db:"note"`
+		}
+
+		s.check(
+			"UPDATE t SET note = ? WHERE street = ? AND city = ?", binds{"x", "Main", "Here"},
+			squint.UpdateByPK("t", keyed{address{"Main", "Here"}, "x"}),
+		)
+	})
+
 	s.Run("no-tag", func() {
 		b := squint.NewBuilder(squint.Tag(""))
 		_, binds := b.Build("SELECT", person{})
@@ -153,6 +261,84 @@ func (s *SquintSuite) TestArray() {
 	)
 }
 
+func (s *SquintSuite) TestRows() {
+	type key struct {
+		OrgID  int `db:"org_id"`
+		UserID int `db:"user_id"`
+	}
+
+	keys := []key{{1, 2}, {3, 4}}
+
+	s.check(
+		"WHERE ( org_id, user_id ) IN ( ( ?, ? ), ( ?, ? ) )", binds{1, 2, 3, 4},
+		"WHERE", keys,
+	)
+
+	s.check(
+		"WHERE (org_id, user_id) IN ( ( ?, ? ), ( ?, ? ) )", binds{1, 2, 3, 4},
+		"WHERE (org_id, user_id) IN", keys,
+	)
+
+	s.check(
+		"WHERE ( org_id, user_id ) IN ( ( :b1, :b2 ), ( :b3, :b4 ) )", binds{1, 2, 3, 4},
+		squint.BindColon(), "WHERE", &keys,
+	)
+
+	s.check(
+		"WHERE ( ( org_id = @p1 AND user_id = @p2 ) OR ( org_id = @p3 AND user_id = @p4 ) )",
+		binds{1, 2, 3, 4},
+		squint.BindAt(), "WHERE", keys,
+	)
+
+	s.check(
+		"WHERE ( a, b ) IN ( ( ?, ? ), ( ?, ? ) )", binds{1, 2, 3, 4},
+		"WHERE", []H{{"a": 1, "b": 2}, {"a": 3, "b": 4}},
+	)
+
+	// differing columns
+	s.check(
+		"WHERE ( ( a = ? ) OR ( a = ? AND b = ? ) )", binds{1, 3, 4},
+		"WHERE", []H{{"a": 1}, {"a": 3, "b": 4}},
+	)
+
+	s.check(
+		"WHERE (a, b) IN ( ( ?, ? ) )", binds{3, 4},
+		"WHERE (a, b) IN", []H{{"a": 1}, {"a": 3, "b": 4}},
+	)
+
+	s.check(
+		"WHERE ( ( a = ? ) )", binds{1},
+		"WHERE", []H{{}, {"a": 1}},
+	)
+
+	s.check(
+		"WHERE (a, b) IN ( ( ?, ? ) )", binds{nil, nil},
+		"WHERE (a, b) IN", []H{{"a": 1}, {"b": 4}},
+	)
+
+	s.check(
+		"WHERE ( org_id, user_id ) IN ( ( ?, ? ) )", binds{nil, nil},
+		"WHERE", []key{},
+	)
+
+	s.check(
+		"WHERE ( org_id, user_id ) IN ( ( ?, ? ) ) AND active = ?", binds{1, 2, true},
+		"WHERE", []*key{{1, 2}}, "AND active =", true,
+	)
+
+	// values that aren't rows
+	s.check(
+		"WHERE name IN ( ?, ? )", binds{sql.NullString{}, sql.NullString{}},
+		"WHERE name IN", []sql.NullString{{}, {}},
+	)
+
+	when := []time.Time{{}, {}}
+	s.check(
+		"WHERE created IN ( ?, ? )", binds{when[0], when[1]},
+		"WHERE created IN", when,
+	)
+}
+
 func (s *SquintSuite) TestInsert() {
 	s.check(
 		"INSERT IGNORE INTO junk ( id, size ) VALUES ( ?, ? )", binds{10, "large"},
@@ -207,6 +393,48 @@ func (s *SquintSuite) TestSet() {
 	)
 }
 
+func (s *SquintSuite) TestWriteTags() {
+	type Row struct {
+		ID      int    `db:"id"`
+		Name    string `db:"name"`
+		Created string `db:"created,noupdate"`
+		Updated string `db:"updated,noinsert"`
+		Total   int    `db:"total,readonly"`
+	}
+
+	row := Row{1, "Frank", "today", "now", 10}
+
+	s.check(
+		"INSERT INTO junk ( id, name, created ) VALUES ( ?, ?, ? )",
+		binds{1, "Frank", "today"},
+		"INSERT INTO junk", row,
+	)
+
+	s.check(
+		"INSERT INTO junk SET id = ?, name = ?, created = ?",
+		binds{1, "Frank", "today"},
+		"INSERT INTO junk SET", row,
+	)
+
+	s.check(
+		"INSERT INTO junk ( id, name, created ) VALUES ( ?, ?, ? ), ( ?, ?, ? )",
+		binds{1, "Frank", "today", 1, "Frank", "today"},
+		"INSERT INTO junk", []Row{row, row},
+	)
+
+	s.check(
+		"UPDATE junk SET id = ?, name = ?, updated = ?",
+		binds{1, "Frank", "now"},
+		"UPDATE junk SET", row,
+	)
+
+	s.check(
+		"WHERE id = ? AND name = ? AND created = ? AND updated = ? AND total = ?",
+		binds{1, "Frank", "today", "now", 10},
+		"WHERE", row,
+	)
+}
+
 func (s *SquintSuite) TestHasValues() {
 	var bar *bool
 
@@ -262,6 +490,91 @@ func (s *SquintSuite) TestIf() {
 	s.check("SELECT ?, ?", binds{10, 20}, "SELECT", s.q.If(true, 10), 20)
 }
 
+func (s *SquintSuite) TestIfElse() {
+	s.check("ORDER BY id", s.empty, squint.IfElse(true, "ORDER BY id", "ORDER BY id DESC"))
+	s.check("ORDER BY id DESC", s.empty, squint.IfElse(false, "ORDER BY id", "ORDER BY id DESC"))
+	s.check("WHERE id = ?", binds{10}, "WHERE", squint.IfElse(true, H{"id": 10}, H{"name": "x"}))
+}
+
+func (s *SquintSuite) TestWhen() {
+	calls := 0
+	test := func(b bool) func() bool {
+		return func() bool {
+			calls++
+			return b
+		}
+	}
+
+	s.check("foo bar", s.empty, "foo", squint.When(test(true), "bar"))
+	s.check("foo", s.empty, "foo", squint.When(test(false), "bar"))
+	s.Equal(2, calls)
+
+	s.check("foo", s.empty, "foo", squint.If(false, squint.When(test(true), "bar")))
+	s.Equal(2, calls)
+}
+
+func (s *SquintSuite) TestSwitch() {
+	order := func(v interface{}) squint.Condition {
+		return squint.Switch(v,
+			squint.Case("name", "ORDER BY name"),
+			squint.Default("ORDER BY id"),
+			squint.Case("age", "ORDER BY age", squint.Bind("x")),
+		)
+	}
+
+	s.check("ORDER BY name", s.empty, order("name"))
+	s.check("ORDER BY age ?", binds{"x"}, order("age"))
+	s.check("ORDER BY id", s.empty, order("other"))
+	s.check("ORDER BY id", s.empty, order(nil))
+	s.check("", s.empty, squint.Switch(1, squint.Case(2, "two")))
+}
+
+func (s *SquintSuite) TestLazy() {
+	calls := 0
+	lazy := squint.Lazy(func() interface{} {
+		calls++
+		return H{"org_id": 5}
+	})
+
+	s.check(
+		"SELECT * FROM users u JOIN orgs o ON o.id = u.org_id AND org_id = ?", binds{5},
+		"SELECT * FROM users u", squint.If(true, "JOIN orgs o ON o.id = u.org_id AND", lazy),
+	)
+	s.Equal(1, calls)
+
+	s.check(
+		"SELECT * FROM users u", s.empty,
+		"SELECT * FROM users u", squint.If(false, "JOIN orgs o ON o.id = u.org_id AND", lazy),
+	)
+	s.Equal(1, calls)
+}
+
+func (s *SquintSuite) TestWith() {
+	type rec struct {
+		Name string
+		Num  int
+	}
+
+	s.check(
+		"UPDATE t SET Name = ? WHERE Name = ? AND Num = ?",
+		binds{"Frank", "Frank", 0},
+		"UPDATE t SET", s.q.With([]squint.Option{squint.OmitEmpty()}, rec{Name: "Frank"}),
+		"WHERE", rec{Name: "Frank"},
+	)
+
+	s.check(
+		"SET Name = ?, Num = ? WHERE Name = ?",
+		binds{nil, 1, "Frank"},
+		"SET", squint.With([]squint.Option{squint.NullEmpty()}, rec{Num: 1}),
+		"WHERE", squint.With(nil, squint.OmitEmpty(), rec{Name: "Frank"}),
+	)
+
+	s.check(
+		"SELECT $1, $2, ?", binds{1, 2, 3},
+		"SELECT", squint.With([]squint.Option{squint.BindDollar()}, 1, 2), 3,
+	)
+}
+
 func (s *SquintSuite) TestLog() {
 	var buf bytes.Buffer
 
@@ -435,6 +748,12 @@ func (s *SquintSuite) TestBinds() {
 	}))
 
 	s.Equal("?", s.q.Binder(1))
+
+	// only an explicit bind option counts
+	s.False(squint.NewBuilder().HasBind())
+	s.False(squint.NewBuilder(squint.OmitEmpty()).HasBind())
+	s.True(squint.NewBuilder(squint.BindQuestion()).HasBind())
+	s.True(squint.NewBuilder(squint.WithBindFn(func(int) string { return "?" })).HasBind())
 }
 
 func (s *SquintSuite) TestValuer() {
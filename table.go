@@ -0,0 +1,244 @@
+package squint
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TableNamer can be implemented by a struct to provide its table name
+type TableNamer interface {
+	TableName() string
+}
+
+// stmtKind is the kind of a struct-driven statement
+type stmtKind uint8
+
+// statement kinds
+const (
+	stmtInsert stmtKind = iota
+	stmtUpdate
+	stmtDelete
+	stmtSelect
+	stmtUpdatePK
+	stmtDeletePK
+)
+
+// Statement is a complete statement built from a struct
+type Statement struct {
+	kind  stmtKind
+	table string
+	src   interface{}
+	where []interface{}
+}
+
+// Insert builds an INSERT statement for a struct (or slice of structs).
+//
+// The table name is found by calling TableName() if the struct implements
+// TableNamer, or from the tag of a blank field:
+//
+//	type User struct {
+//	  _    struct{} `db:"users"`
+//	  ID   int      `db:"id"`
+//	  Name string   `db:"name"`
+//	}
+//
+// Failing both, such as for a map, there is no table name and the
+// statement is left out.
+func Insert(src interface{}) Statement {
+	return Statement{kind: stmtInsert, src: src}
+}
+
+// Update builds an UPDATE statement for a struct.
+// The where bits, if any, follow a WHERE.
+//
+//	b.Build(squint.Update(user, "id =", user.ID))
+func Update(src interface{}, where ...interface{}) Statement {
+	return Statement{kind: stmtUpdate, src: src, where: where}
+}
+
+// Delete builds a DELETE statement using a struct as the WHERE clause
+func Delete(src interface{}) Statement {
+	return Statement{kind: stmtDelete, src: src}
+}
+
+// Select builds a SELECT statement for the columns of a struct.
+// The where bits, if any, follow a WHERE.
+//
+//	b.Build(squint.Select(User{}, "id =", 10))
+func Select(src interface{}, where ...interface{}) Statement {
+	return Statement{kind: stmtSelect, src: src, where: where}
+}
+
+// UpdateByPK builds an UPDATE statement for a struct with primary key
+// fields, which are tagged with "pk":
+//
+//	type User struct {
+//	  ID   int    `db:"id,pk"`
+//	  Name string `db:"name"`
+//	}
+//
+// The primary key columns make up the WHERE clause and all others are SET.
+// If table is empty, it is found as with Insert(). Only a struct (or a
+// pointer to one) is accepted, and without any primary key fields, the
+// statement is left out.
+func UpdateByPK(table string, src interface{}) Statement {
+	return Statement{kind: stmtUpdatePK, table: table, src: src}
+}
+
+// DeleteByPK builds a DELETE statement using the primary key fields
+// of a struct as the WHERE clause. If table is empty, it is found as
+// with Insert(). As with UpdateByPK(), only a struct is accepted, and
+// without any primary key fields, the statement is left out.
+func DeleteByPK(table string, src interface{}) Statement {
+	return Statement{kind: stmtDeletePK, table: table, src: src}
+}
+
+// addStatement adds a struct-driven statement to the query
+func (q *query) addStatement(s Statement) {
+	v := reflect.ValueOf(s.src)
+
+	table := s.table
+	if table == "" {
+		table = q.tableName(v)
+	}
+
+	// no statement without a table
+	if table == "" {
+		return
+	}
+
+	switch s.kind {
+	case stmtInsert:
+		q.sql.Add("INSERT INTO " + table)
+		q.Add(s.src)
+	case stmtUpdate:
+		q.sql.Add("UPDATE " + table + " SET")
+		q.Add(s.src)
+	case stmtDelete:
+		q.sql.Add("DELETE FROM " + table + " WHERE")
+		q.Add(s.src)
+	case stmtSelect:
+		cols := q.columns(v)
+		if len(cols) == 0 {
+			cols = []string{"*"}
+		}
+
+		q.sql.Add("SELECT " + strings.Join(cols, ", ") + " FROM " + table)
+	case stmtUpdatePK:
+		if !q.hasPK(v) {
+			return
+		}
+
+		q.sql.Add("UPDATE " + table + " SET")
+		q.pk = pkNone
+		q.Add(s.src)
+		q.sql.Add("WHERE")
+		q.addPK(s.src)
+	case stmtDeletePK:
+		if !q.hasPK(v) {
+			return
+		}
+
+		q.sql.Add("DELETE FROM " + table + " WHERE")
+		q.addPK(s.src)
+	}
+
+	if len(s.where) > 0 {
+		q.sql.Add("WHERE")
+
+		for _, bit := range s.where {
+			q.Add(bit)
+		}
+	}
+}
+
+// addPK adds the primary key columns of a struct as a WHERE clause.
+// Every key column is kept, regardless of empty mode.
+func (q *query) addPK(src interface{}) {
+	q.pk = pkOnly
+	q.keepAll = true
+	q.Add(src)
+	q.keepAll = false
+	q.pk = pkAll
+}
+
+// hasPK reports whether a value is a struct with primary key columns
+func (q *query) hasPK(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	q.pk = pkOnly
+	q.keepAll = true
+	cols, _ := q.sift(&v)
+	q.keepAll = false
+	q.pk = pkAll
+
+	return len(cols) > 0
+}
+
+// columns returns all columns mapped from a struct (or slice of structs)
+func (q *query) columns(v reflect.Value) []string {
+	v = elemValue(v)
+
+	q.keepAll = true
+	cols, _ := q.sift(&v)
+	q.keepAll = false
+
+	return cols
+}
+
+// tableName finds the table name for a struct (or slice of structs)
+func (q *query) tableName(v reflect.Value) string {
+	v = elemValue(v)
+	if !v.IsValid() {
+		return ""
+	}
+
+	// check for TableNamer, with pointer or value receiver
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	if namer, ok := ptr.Interface().(TableNamer); ok {
+		return namer.TableName()
+	}
+
+	// check for a tagged blank field
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.Name == "_" {
+				if tag := strings.Split(q.tagValue(field), ",")[0]; tag != "" && tag != "-" {
+					return tag
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// elemValue dereferences pointers, and for a slice or array it
+// returns an empty element value
+func elemValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem())
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		v = elemValue(reflect.New(v.Type().Elem()).Elem())
+	}
+
+	return v
+}
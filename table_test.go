@@ -0,0 +1,115 @@
+package squint_test
+
+import "github.com/mwblythe/squint"
+
+type tableUser struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func (tableUser) TableName() string {
+	return "users"
+}
+
+type tableTeam struct {
+	_    struct{} `db:"teams"`
+	ID   int      `db:"id"`
+	Name string   `db:"name"`
+}
+
+type tablePlain struct {
+	ID int
+}
+
+type tableMap map[string]interface{}
+
+func (s *SquintSuite) TestTableName() {
+	s.check("DELETE FROM users WHERE id = ? AND name = ?", binds{1, "Frank"}, squint.Delete(tableUser{1, "Frank"}))
+	s.check("DELETE FROM users WHERE id = ? AND name = ?", binds{1, "Frank"}, squint.Delete(&tableUser{1, "Frank"}))
+	s.check("DELETE FROM teams WHERE id = ? AND name = ?", binds{2, "Red"}, squint.Delete(tableTeam{ID: 2, Name: "Red"}))
+
+	// no table name
+	s.check("", s.empty, squint.Delete(tablePlain{3}))
+	s.check("", s.empty, squint.Insert([]tablePlain{{3}}))
+	s.check("", s.empty, squint.Insert(tableMap{"a": 1}))
+	s.check("", s.empty, squint.Select(H{"a": 1}))
+	s.check("", s.empty, squint.Insert(H{"a": 1}))
+	s.check("", s.empty, squint.Delete(struct{ A int }{1}))
+}
+
+func (s *SquintSuite) TestStatements() {
+	user := tableUser{1, "Frank"}
+
+	s.check(
+		"INSERT INTO users ( id, name ) VALUES ( ?, ? )", binds{1, "Frank"},
+		squint.Insert(user),
+	)
+
+	s.check(
+		"INSERT INTO users ( id, name ) VALUES ( ?, ? ), ( ?, ? )", binds{1, "Frank", 2, "Hank"},
+		squint.Insert([]tableUser{user, {2, "Hank"}}),
+	)
+
+	s.check(
+		"UPDATE users SET id = ?, name = ? WHERE id = ?", binds{1, "Frank", 1},
+		squint.Update(user, "id =", user.ID),
+	)
+
+	s.check(
+		"UPDATE teams SET name = ?", binds{"Blue"},
+		squint.OmitEmpty(), squint.Update(tableTeam{Name: "Blue"}),
+	)
+
+	s.check(
+		"SELECT id, name FROM users WHERE id = ?", binds{1},
+		squint.Select(tableUser{}, H{"id": 1}),
+	)
+
+	s.check(
+		"SELECT id, name FROM teams", s.empty,
+		squint.Select([]tableTeam{}),
+	)
+}
+
+func (s *SquintSuite) TestPrimaryKey() {
+	type member struct {
+		OrgID  int    `db:"org_id,pk"`
+		UserID int    `db:"user_id,pk"`
+		Role   string `db:"role"`
+		Notes  string `db:"notes,omitempty"`
+	}
+
+	m := member{OrgID: 1, UserID: 0, Role: "admin"}
+
+	s.check(
+		"UPDATE members SET role = ? WHERE org_id = ? AND user_id = ?", binds{"admin", 1, 0},
+		squint.UpdateByPK("members", m),
+	)
+
+	s.check(
+		"UPDATE users SET name = ? WHERE id = ?", binds{"Frank", 0},
+		squint.OmitEmpty(), squint.UpdateByPK("", struct {
+			_    struct{} `db:"users"`
+			ID   int      `db:"id,pk"`
+			Name string   `db:"name"`
+		}{Name: "Frank"}),
+	)
+
+	s.check(
+		"DELETE FROM members WHERE org_id = ? AND user_id = ?", binds{1, 0},
+		squint.DeleteByPK("members", &m),
+	)
+
+	// only structs with key columns
+	s.check("", s.empty, squint.UpdateByPK("members", H{"org_id": 1, "role": "admin"}))
+	s.check("", s.empty, squint.DeleteByPK("members", H{"org_id": 1}))
+	s.check("", s.empty, squint.DeleteByPK("users", tableUser{1, "Frank"}))
+	s.check("", s.empty, squint.UpdateByPK("users", &tableUser{1, "Frank"}))
+	s.check("", s.empty, squint.DeleteByPK("members", (*member)(nil)))
+
+	// pk flag has no effect elsewhere
+	s.check(
+		"WHERE org_id = ? AND user_id = ? AND role = ?", binds{1, 0, "admin"},
+		"WHERE", m,
+	)
+}
@@ -0,0 +1,84 @@
+package squint
+
+import "reflect"
+
+// Handler converts a value of a registered type into a bit, which is
+// then processed like any other. For example, it could return:
+//
+//	squint.Bind(s)          // a single bind
+//	"CURRENT_TIMESTAMP"     // a raw SQL expression
+//	[]interface{}{"ST_GeomFromText(", squint.Bind(wkt), ")"} // both
+//	map[string]interface{}  // a column expansion
+//
+// Within a struct or map, the result takes the place of the bind for a
+// column, so a column expansion only applies when the value is passed
+// to Build directly. If the handler returns a value of the same type,
+// it is bound as-is.
+type Handler func(v interface{}) interface{}
+
+// RegisterType registers a handler for values of the given type.
+// This is useful for types that can't implement driver.Valuer, and
+// takes precedence over it. Types should be registered before the
+// Builder is in use.
+//
+//	b.RegisterType(reflect.TypeOf(decimal.Decimal{}), func(v interface{}) interface{} {
+//	  return squint.Bind(v.(decimal.Decimal).String())
+//	})
+func (b *Builder) RegisterType(t reflect.Type, h Handler) {
+	types := make(map[reflect.Type]Handler, len(b.types)+1)
+	for k, v := range b.types {
+		types[k] = v
+	}
+
+	types[t] = h
+	b.types = types
+}
+
+// handler returns the handler registered for a value's type, if any
+func (q *query) handler(v interface{}) (Handler, bool) {
+	if len(q.opt.types) == 0 {
+		return nil, false
+	}
+
+	h, ok := q.opt.types[reflect.TypeOf(v)]
+
+	return h, ok
+}
+
+// addHandled adds a value to the query via its handler
+func (q *query) addHandled(h Handler, v interface{}) {
+	out := h(v)
+
+	if reflect.TypeOf(out) == reflect.TypeOf(v) {
+		q.addBind(out)
+	} else {
+		q.Add(out)
+	}
+}
+
+// addValue adds a value as a bind, or via its handler if registered
+func (q *query) addValue(v interface{}) {
+	if h, ok := q.handler(v); ok {
+		q.addHandled(h, v)
+	} else {
+		q.addBind(v)
+	}
+}
+
+// addValues adds a comma separated list of values
+func (q *query) addValues(values ...interface{}) {
+	for i, v := range values {
+		h, handled := q.handler(v)
+
+		// binds add their own comma, after other binds
+		if i > 0 && (handled || !q.sql.lastWasBind) {
+			q.sql.Add(",")
+		}
+
+		if handled {
+			q.addHandled(h, v)
+		} else {
+			q.addBind(v)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package squint_test
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mwblythe/squint"
+)
+
+type point struct {
+	X, Y int
+}
+
+type cents int
+
+func (s *SquintSuite) TestRegisterType() {
+	orig := s.q
+	defer func() { s.q = orig }()
+
+	s.q = squint.NewBuilder()
+
+	s.q.RegisterType(reflect.TypeOf(point{}), func(v interface{}) interface{} {
+		p := v.(point)
+		return []interface{}{"POINT(", p.X, ",", p.Y, ")"}
+	})
+
+	s.q.RegisterType(reflect.TypeOf(cents(0)), func(v interface{}) interface{} {
+		return squint.Bind(fmt.Sprintf("%.2f", float64(v.(cents))/100))
+	})
+
+	s.Run("direct", func() {
+		s.check("SELECT POINT( ?, ? )", binds{1, 2}, "SELECT", point{1, 2})
+		s.check("SELECT ?", binds{"1.50"}, "SELECT", cents(150))
+
+		p := &point{3, 4}
+		s.check("SELECT POINT( ?, ? )", binds{3, 4}, "SELECT", p)
+	})
+
+	s.Run("in", func() {
+		s.check("WHERE price IN ( ?, ? )", binds{"1.00", "2.00"}, "WHERE price IN", []cents{100, 200})
+		s.check("WHERE loc IN ( POINT( ?, ? ), POINT( ?, ? ) )", binds{1, 2, 3, 4}, "WHERE loc IN", []point{{1, 2}, {3, 4}})
+	})
+
+	type place struct {
+		ID    int   `db:"id"`
+		Loc   point `db:"loc"`
+		Price cents `db:"price"`
+	}
+
+	s.Run("struct", func() {
+		pl := place{1, point{5, 6}, 250}
+
+		s.check(
+			"INSERT INTO places ( id, loc, price ) VALUES ( ?, POINT( ?, ? ), ? )",
+			binds{1, 5, 6, "2.50"},
+			"INSERT INTO places", pl,
+		)
+
+		s.check(
+			"UPDATE places SET id = ?, loc = POINT( ?, ? ), price = ?",
+			binds{1, 5, 6, "2.50"},
+			"UPDATE places SET", pl,
+		)
+
+		s.check(
+			"WHERE id = ? AND loc = POINT( ?, ? ) AND price = ?",
+			binds{1, 5, 6, "2.50"},
+			"WHERE", pl,
+		)
+	})
+
+	s.Run("expand", func() {
+		b := squint.NewBuilder()
+		b.RegisterType(reflect.TypeOf(point{}), func(v interface{}) interface{} {
+			p := v.(point)
+			return H{"x": p.X, "y": p.Y}
+		})
+
+		sql, vals := b.Build("WHERE", point{7, 8})
+		s.Equal("WHERE x = ? AND y = ?", sql)
+		s.Equal(binds{7, 8}, vals)
+	})
+
+	s.Run("same", func() {
+		b := squint.NewBuilder()
+		b.RegisterType(reflect.TypeOf(point{}), func(v interface{}) interface{} {
+			return v
+		})
+
+		sql, vals := b.Build("SELECT", point{1, 2})
+		s.Equal("SELECT ?", sql)
+		s.Equal(binds{point{1, 2}}, vals)
+	})
+}
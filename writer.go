@@ -0,0 +1,73 @@
+package squint
+
+// Appender can be implemented by a type to write its own SQL when
+// passed to Build. This allows for reusable query pieces:
+//
+//	type ActiveUsers struct {
+//	  Since time.Time
+//	}
+//
+//	func (a ActiveUsers) AppendSQL(w *squint.Writer) {
+//	  w.SQL("active = 1 AND last_login >=")
+//	  w.Bind(a.Since)
+//	}
+//
+//	b.Build("SELECT * FROM users WHERE", ActiveUsers{since})
+type Appender interface {
+	AppendSQL(w *Writer)
+}
+
+// Clause is the kind of clause being built, which determines how
+// structs, maps and slices are handled
+type Clause uint8
+
+// Clauses
+const (
+	ClauseOther  Clause = iota // anything else, such as WHERE
+	ClauseInsert               // after INSERT INTO table
+	ClauseSet                  // after SET
+	ClauseIn                   // after IN
+)
+
+// Writer adds to the query being built, on behalf of an Appender
+type Writer struct {
+	q *query
+}
+
+// SQL adds SQL fragments to the query
+func (w *Writer) SQL(fragments ...string) {
+	for _, sql := range fragments {
+		w.q.sql.Add(sql)
+	}
+}
+
+// Bind adds values to the query as binds
+func (w *Writer) Bind(values ...interface{}) {
+	w.q.addBind(values...)
+}
+
+// Add adds bits to the query, just as if they were passed to Build
+func (w *Writer) Add(bits ...interface{}) {
+	for _, bit := range bits {
+		w.q.Add(bit)
+	}
+}
+
+// Clause returns the kind of clause currently being built
+func (w *Writer) Clause() Clause {
+	switch w.q.state() {
+	case stateInsert:
+		return ClauseInsert
+	case stateSet:
+		return ClauseSet
+	case stateIn:
+		return ClauseIn
+	default:
+		return ClauseOther
+	}
+}
+
+// addAppender lets an Appender add to the query
+func (q *query) addAppender(a Appender) {
+	a.AppendSQL(&Writer{q: q})
+}
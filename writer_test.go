@@ -0,0 +1,43 @@
+package squint_test
+
+import (
+	"github.com/mwblythe/squint"
+)
+
+type activeUsers struct {
+	Since string
+}
+
+func (a activeUsers) AppendSQL(w *squint.Writer) {
+	w.SQL("active = 1", "AND last_login >=")
+	w.Bind(a.Since)
+}
+
+type clauseName struct{}
+
+func (c *clauseName) AppendSQL(w *squint.Writer) {
+	switch w.Clause() {
+	case squint.ClauseInsert:
+		w.Add(H{"name": "insert"})
+	case squint.ClauseSet:
+		w.Add(H{"name": "set"})
+	case squint.ClauseIn:
+		w.Add([]string{"in"})
+	default:
+		w.Add(H{"name": "other"})
+	}
+}
+
+func (s *SquintSuite) TestAppender() {
+	s.check(
+		"SELECT * FROM users WHERE active = 1 AND last_login >= ? LIMIT ?",
+		binds{"2020-01-01", 10},
+		"SELECT * FROM users WHERE", activeUsers{"2020-01-01"}, squint.Page(10, 0),
+	)
+
+	c := &clauseName{}
+	s.check("INSERT INTO t ( name ) VALUES ( ? )", binds{"insert"}, "INSERT INTO t", c)
+	s.check("UPDATE t SET name = ?", binds{"set"}, "UPDATE t SET", c)
+	s.check("WHERE name IN ( ? )", binds{"in"}, "WHERE name IN", c)
+	s.check("WHERE name = ?", binds{"other"}, "WHERE", c)
+}